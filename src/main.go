@@ -1,29 +1,57 @@
 package main
 
 import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"embed"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
+	"text/tabwriter"
+	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 //go:embed agents/*.md
 var agentTemplates embed.FS
 
 const (
-	autom8Dir = ".autom8"
-	tasksFile = "tasks.json"
-	pidsFile  = "pids.json"
+	autom8Dir     = ".autom8"
+	tasksFile     = "tasks.json"
+	pidsFile      = "pids.json"
+	statsFile     = "worktree_stats.json"
+	tasksLockFile = "tasks.lock"
+	queueFile     = "queue.json"
+	queueLockFile = "queue.lock"
+	configFile    = "config.yaml"
 )
 
 // Styles for terminal output
@@ -61,13 +89,148 @@ var (
 )
 
 type Task struct {
-	ID                   string    `json:"id"`
-	Prompt               string    `json:"prompt"`
-	VerificationCriteria []string  `json:"verification_criteria"`
-	DependsOn            string    `json:"depends_on,omitempty"`
-	CreatedAt            time.Time `json:"created_at"`
-	Status               string    `json:"status"`
-	Winner               string    `json:"winner,omitempty"` // Winning worktree name from converge
+	ID                   string         `json:"id"`
+	Prompt               string         `json:"prompt"`
+	VerificationCriteria []string       `json:"verification_criteria"`
+	VerifyCommand        string         `json:"verify_command,omitempty"` // Shell command that checks the work; see implement --auto-verify
+	DependsOn            string         `json:"depends_on,omitempty"`
+	Epic                 string         `json:"epic,omitempty"` // Grouping for status and implement --epic; implies no ordering
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at,omitzero"`
+	Status               string         `json:"status"`
+	Winner               string         `json:"winner,omitempty"`          // Winning worktree name from converge
+	WorktreeScores       map[string]int `json:"worktree_scores,omitempty"` // Converge's 1-10 score per worktree
+	ExternalRef          *ExternalRef   `json:"external_ref,omitempty"`    // Linked issue in an external tracker
+	NotBefore            time.Time      `json:"not_before,omitzero"`       // Scheduled start; implement and watch leave the task alone until then
+}
+
+// scheduledAfter reports whether the task is scheduled to start after now.
+func (t Task) scheduledAfter(now time.Time) bool {
+	return t.NotBefore.After(now)
+}
+
+// validStatuses lists the task statuses in lifecycle order
+var validStatuses = []string{"pending", "in-progress", "completed"}
+
+// ExternalRef links a task to the issue it was imported from
+type ExternalRef struct {
+	Provider string `json:"provider"` // "github" or "gitlab"
+	Repo     string `json:"repo"`     // owner/name or group/project
+	Number   int    `json:"number"`
+	URL      string `json:"url,omitempty"`
+}
+
+// Config holds optional per-repository settings from .autom8/config.yaml
+type Config struct {
+	Agent         AgentConfig         `yaml:"agent"`
+	Issues        IssuesConfig        `yaml:"issues"`
+	Forge         ForgeConfig         `yaml:"forge"`
+	Accept        AcceptConfig        `yaml:"accept"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Hooks         HooksConfig         `yaml:"hooks"`
+	Server        ServerConfig        `yaml:"server"`
+	Queue         QueueConfig         `yaml:"queue"`
+	Implement     ImplementConfig     `yaml:"implement"`
+	Logs          LogsConfig          `yaml:"logs"`
+
+	WebhookURL     string            `yaml:"webhook_url"`     // POSTed a task_completed payload after each merge
+	WebhookHeaders map[string]string `yaml:"webhook_headers"` // Extra request headers, e.g. Authorization
+}
+
+// AgentConfig controls how agent CLIs are invoked
+type AgentConfig struct {
+	MCPConfig string `yaml:"mcp_config"` // MCP server config file, relative to the git root
+}
+
+// IssuesConfig controls integration with linked issues
+type IssuesConfig struct {
+	SyncStatus bool `yaml:"sync_status"` // Comment on and close linked issues as tasks progress
+}
+
+// AcceptConfig controls what accept does after a successful merge
+type AcceptConfig struct {
+	Push   bool   `yaml:"push"`   // Push the current branch after merging
+	Remote string `yaml:"remote"` // Remote to push to (default: origin)
+
+	StartDependents bool `yaml:"start_dependents"` // Implement pending dependents of the merged task, see --start-dependents
+	PruneSiblings   bool `yaml:"prune_siblings"`   // Remove the task's other worktrees and branches, see --prune-siblings
+}
+
+// NotificationsConfig controls webhook notifications for lifecycle events
+type NotificationsConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+	Template   string `yaml:"template"` // Optional text/template for the request body, e.g. for Slack or Discord
+
+	Desktop            bool `yaml:"desktop"`              // Desktop notification when implement or converge finishes
+	DesktopPerWorktree bool `yaml:"desktop_per_worktree"` // Also notify as each worktree finishes
+}
+
+// ServerConfig controls the 'autom8 serve' API
+type ServerConfig struct {
+	Token string `yaml:"token"` // Bearer token required by every request (default: $AUTOM8_SERVER_TOKEN)
+}
+
+// QueueConfig controls the agent queue shared by every autom8 process
+type QueueConfig struct {
+	MaxAgents int `yaml:"max_agents"` // Agents allowed to run at once across all processes (0: unlimited)
+}
+
+// ImplementConfig holds defaults for implement flags
+type ImplementConfig struct {
+	AutoConverge string `yaml:"auto_converge"` // off, on or merge; see --auto-converge
+}
+
+// LogsConfig controls what is kept in .autom8/logs
+type LogsConfig struct {
+	MaxWorktreeMB int `yaml:"max_worktree_mb"` // Cap on each worktree's logs; transcripts past it are truncated (0: unlimited)
+}
+
+// HooksConfig holds shell commands run on lifecycle events. Each gets
+// AUTOM8_EVENT, AUTOM8_TASK_ID, AUTOM8_WORKTREE and AUTOM8_RESULT in its
+// environment and the notification payload as JSON on stdin.
+type HooksConfig struct {
+	OnWorktreeComplete string        `yaml:"on_worktree_complete"`
+	OnWorktreeFailed   string        `yaml:"on_worktree_failed"`
+	OnWorktreeStopped  string        `yaml:"on_worktree_stopped"`
+	OnConverge         string        `yaml:"on_converge"`
+	PreAccept          string        `yaml:"pre_accept"` // Exiting non-zero vetoes the merge
+	OnAccept           string        `yaml:"on_accept"`
+	Timeout            time.Duration `yaml:"timeout"` // Per hook and per converge criteria check, default 5m
+}
+
+// timeout returns how long a hook or criteria check may run.
+func (h HooksConfig) timeout() time.Duration {
+	if h.Timeout <= 0 {
+		return 5 * time.Minute
+	}
+	return h.Timeout
+}
+
+// command returns the hook configured for an event, or "".
+func (h HooksConfig) command(event string) string {
+	switch event {
+	case "worktree_completed":
+		return h.OnWorktreeComplete
+	case "worktree_failed":
+		return h.OnWorktreeFailed
+	case "worktree_stopped":
+		return h.OnWorktreeStopped
+	case "converge_winner":
+		return h.OnConverge
+	case "pre_accept":
+		return h.PreAccept
+	case "accept_merged":
+		return h.OnAccept
+	}
+	return ""
+}
+
+// ForgeConfig overrides the code host detected from the origin remote
+type ForgeConfig struct {
+	Type    string `yaml:"type"`     // "github" or "gitlab"
+	BaseURL string `yaml:"base_url"` // For GitHub Enterprise or self-hosted GitLab
+	Token   string `yaml:"token"`    // Defaults to $GITHUB_TOKEN / $GITLAB_TOKEN
+	Repo    string `yaml:"repo"`     // owner/name or group/project, defaults to the origin path
 }
 
 var rootCmd = &cobra.Command{
@@ -80,8 +243,8 @@ It enables you to:
   - Manage task dependencies
   - Run multiple Claude AI agents in parallel
   - Isolate each agent's work in separate git worktrees`,
-	SilenceUsage:          true,
-	CompletionOptions:     cobra.CompletionOptions{DisableDefaultCmd: true},
+	SilenceUsage:      true,
+	PersistentPreRunE: requireGitRepo,
 }
 
 var newCmd = &cobra.Command{
@@ -90,7 +253,12 @@ var newCmd = &cobra.Command{
 	Long: `Create a new task with a prompt and optional verification criteria.
 
 Without flags, starts an interactive mode to guide you through task creation.
-With flags, creates the task directly (non-interactive mode).`,
+With flags, creates the task directly (non-interactive mode).
+
+With --batch, creates every task defined in a YAML or JSON file: a list of
+entries with prompt, criteria, verify_command, epic and depends_on, plus an
+optional key that other entries' depends_on can refer to. The whole file is
+validated first (no unknown keys, no cycles), so a bad file creates nothing.`,
 	Example: `  # Interactive mode
   autom8 new
 
@@ -98,7 +266,13 @@ With flags, creates the task directly (non-interactive mode).`,
   autom8 new -p "Add login page" -c "Has email field" -c "Has password field"
 
   # With dependency
-  autom8 new -p "Add logout button" -d task-123456789`,
+  autom8 new -p "Add logout button" -d task-123456789
+
+  # Create and implement right away (same as new, then implement <id>)
+  autom8 new -p "Add login page" --auto-implement -n 3
+
+  # Create a set of related tasks from a file
+  autom8 new --batch tasks.yaml`,
 	RunE: runFeature,
 }
 
@@ -108,7 +282,8 @@ var implementCmd = &cobra.Command{
 	Long: `Launch Claude AI agents to implement pending tasks.
 
 If a task ID is provided, only that task will be implemented.
-Otherwise, all pending tasks will be implemented.
+Otherwise, all pending tasks will be implemented (or the first N, oldest
+first, with --limit N).
 
 Each agent runs in an isolated git worktree, allowing multiple parallel
 implementations without conflicts. For dependent tasks, the branching
@@ -120,13 +295,80 @@ instance of its parent task.`,
   # Implement a specific task
   autom8 implement task-123456789
 
+  # Work through the backlog five tasks at a time
+  autom8 implement --limit 5
+
+  # Done when the tests pass, whatever the agent says
+  autom8 implement --verify-after "make test"
+
+  # Start overnight (blocks until then; --no-wait leaves it to watch)
+  autom8 implement --at 01:00
+  autom8 implement --after 6h --no-wait
+
   # Multiple parallel implementations
   autom8 implement -n 3
-  autom8 implement task-123456789 -n 3`,
+  autom8 implement task-123456789 -n 3
+
+  # Label the branches (autom8/auth-refactor-task-123456789-1)
+  autom8 implement task-123456789 --label auth-refactor
+
+  # Extra guidance for this run only
+  autom8 implement --prompt-append "Focus on tests, don't touch the API"
+
+  # Stop starting new iterations after $5 or 30 minutes
+  autom8 implement -n 3 --budget-usd 5 --budget-time 30m
+
+  # Branch dependents only from their parent's converge winner
+  autom8 implement -n 3 --parent-strategy winner
+
+  # One-off prompt without creating a task
+  echo "Fix the typo in the README" | autom8 implement --stdin-prompt`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runImplement,
 }
 
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create, implement, converge and accept a task in one go",
+	Long: `Run the whole pipeline for a single change:
+
+  1. Create a task from -p and -c
+  2. Implement it with -n instances and wait for them to finish
+  3. Converge when more than one worktree completed
+  4. With --auto-accept, accept the winner (hooks.pre_accept can veto it)
+
+A failing stage stops the pipeline and leaves the task and worktrees in
+place, so it can be continued with implement, converge or accept.`,
+	Example: `  autom8 run -p "Rename Config.Foo to Config.Bar everywhere" -c "builds" -n 3 --auto-accept`,
+	Args:    cobra.NoArgs,
+	RunE:    runPipeline,
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Implement new pending tasks as they appear",
+	Long: `Watch tasks.json and start implementing pending tasks as soon as they
+appear, e.g. when created with 'autom8 new' or imported from another terminal.
+
+Dependent tasks are held back until their parent task is completed, and then
+branch from main. Use --max-parallel to cap how many worktrees run at once;
+the rest wait in a queue.
+
+On SIGINT or SIGTERM, queued tasks that never started go back to pending.
+With --on-exit kill (the default) running agents are killed and their
+worktrees marked stopped. With --on-exit detach, agents run in their own
+session, so Ctrl+C in the terminal doesn't reach them, and each finishes
+its current iteration after watch exits; the worktree is not iterated
+further.`,
+	Example: `  # Two instances per task, at most three worktrees at a time
+  autom8 watch -n 2 --max-parallel 3
+
+  # Leave running agents alone when watch is stopped
+  autom8 watch --on-exit detach`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
 var statusCmd = &cobra.Command{
 	Use:     "status",
 	Aliases: []string{"ls", "list"},
@@ -137,23 +379,105 @@ Shows a tree structure with:
   - Task status, prompt, and verification criteria
   - Dependent tasks nested under their parents
   - Worktrees for each task with their git status
-  - Hints for accepting completed implementations`,
+  - Hints for accepting completed implementations
+
+With --output json, status prints the same tree as JSON (each task with its
+worktrees and children), while list prints a flat array of tasks.`,
+	Example: `  autom8 status
+  autom8 list --output json | jq -r '.[] | select(.status == "pending") | .id'`,
 	RunE: runStatus,
 }
 
+var statusSetCmd = &cobra.Command{
+	Use:   "set <task-id> <pending|in-progress|completed>",
+	Short: "Manually set a task's status",
+	Long: `Set a task's status directly.
+
+Statuses normally change as a side effect of other commands (implement marks
+tasks in-progress, accept marks them completed). Use this to mark a task done
+without merging, or to return it to pending after a mistake.`,
+	Example: `  autom8 status set task-123456789 completed
+  autom8 status set task-123456789 pending`,
+	Args: cobra.ExactArgs(2),
+	RunE: runStatusSet,
+}
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "List worktrees waiting for an agent slot and scheduled tasks",
+	Long: `List the agent queue shared by every autom8 process.
+
+implement, run and watch queue each worktree in .autom8/queue.json and start
+its agent once one of the queue.max_agents slots in .autom8/config.yaml is
+free (unlimited by default). Jobs start in queue order.
+
+Tasks scheduled with 'autom8 implement --at/--after' are listed with the time
+they start.`,
+	Example: `  autom8 queue
+  autom8 queue move task-123456789-2 1
+  autom8 queue cancel task-123456789-3
+  autom8 queue cancel task-123456789`,
+	Args: cobra.NoArgs,
+	RunE: runQueue,
+}
+
+var queueMoveCmd = &cobra.Command{
+	Use:   "move <worktree-name> <position>",
+	Short: "Move a queued worktree to a position in the queue",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runQueueMove,
+}
+
+var queueCancelCmd = &cobra.Command{
+	Use:   "cancel <worktree-name|task-id>...",
+	Short: "Remove queued worktrees or scheduled runs before they start",
+	Long: `Remove worktrees from the queue before their agents start, or clear the
+schedule of tasks given by ID.
+
+A task left without worktrees or queued jobs returns to pending. A task whose
+schedule is cleared stays pending; note that a running 'autom8 watch' starts
+pending tasks right away.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runQueueCancel,
+}
+
 var acceptCmd = &cobra.Command{
-	Use:   "accept <worktree-name>",
+	Use:   "accept <worktree-name|task-id>",
 	Short: "Merge a worktree branch into current branch and clean up",
 	Long: `Accept and merge a completed implementation from a worktree.
 
+Given a task ID, the task's converge winner is accepted, or its only
+worktree. When it has several and no winner, --interactive-select lists
+them with their commits ahead and changes to pick from.
+
 This command will:
   1. Auto-commit any uncommitted changes in the worktree
-  2. Merge the worktree's branch into your current branch
-  3. Remove the worktree directory
-  4. Delete the merged branch`,
-	Example: `  autom8 accept task-123456789-1`,
-	Args:    cobra.ExactArgs(1),
-	RunE:    runAccept,
+  2. Merge the worktree's branch into your current branch (or --into)
+  3. Remove the worktree directory (unless --keep-worktree)
+  4. Delete the merged branch (unless --keep-branch)
+  5. Push the current branch, with --push or accept.push in config
+
+With --pr, the branch is pushed to origin and a pull request (GitHub) or
+merge request (GitLab) is opened instead of merging locally. The worktree
+is kept so that review feedback can be addressed there.`,
+	Example: `  autom8 accept task-123456789-1
+
+  # Merge and push the current branch to origin
+  autom8 accept task-123456789-1 --push
+
+  # Merge but keep the branch around for reference
+  autom8 accept task-123456789-1 --keep-branch
+
+  # Merge onto a release branch without switching to it
+  autom8 accept task-123456789-1 --into release/1.2
+
+  # Push the branch and open a pull/merge request
+  autom8 accept task-123456789-1 --pr
+
+  # Pick one of a task's worktrees without converging first
+  autom8 accept task-123456789 --interactive-select`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAccept,
 }
 
 var deleteCmd = &cobra.Command{
@@ -162,11 +486,23 @@ var deleteCmd = &cobra.Command{
 	Short:   "Delete a task by ID",
 	Long: `Delete a task from the task list.
 
+The task's worktrees, autom8/ branches, logs and PID entries are removed
+with it. Worktrees whose agent is still running are left in place unless
+--kill is given, which stops the agent first.
+
 Note: Tasks that have other tasks depending on them cannot be deleted
-until their dependents are deleted first.`,
-	Example: `  autom8 delete task-123456789`,
-	Args:    cobra.ExactArgs(1),
-	RunE:    runDelete,
+until their dependents are deleted first. Use --cascade to delete the
+task together with everything that depends on it, directly or not; it
+lists everything that will be destroyed and asks for confirmation.`,
+	Example: `  autom8 delete task-123456789
+
+  # Also delete all dependent tasks and their worktrees
+  autom8 delete task-123456789 --cascade
+
+  # Stop running agents too, without asking
+  autom8 delete task-123456789 --cascade --kill --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDelete,
 }
 
 var inspectCmd = &cobra.Command{
@@ -175,10 +511,194 @@ var inspectCmd = &cobra.Command{
 	Long: `Open a new shell in the specified worktree directory.
 
 This allows you to inspect the implementation, run tests, or make manual changes.
-To return to your original directory, simply exit the shell (Ctrl+D or 'exit').`,
-	Example: `  autom8 inspect task-123456789-1`,
+To return to your original directory, simply exit the shell (Ctrl+D or 'exit').
+
+To run a single command there instead, give it after -- (run directly) or
+with --command (run through the shell); it inherits stdio and autom8 exits
+with its exit code. Without a terminal one of the two is required.`,
+	Example: `  autom8 inspect task-123456789-1
+
+  # Run one command in the worktree
+  autom8 inspect task-123456789-1 -- go test ./...
+  autom8 inspect task-123456789-1 --command "go test ./... | tail"`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("requires a worktree name")
+		}
+		if dash := cmd.ArgsLenAtDash(); dash == -1 && len(args) > 1 || dash > 1 {
+			return fmt.Errorf("expected one worktree name; put the command to run after --")
+		}
+		if len(args) > 1 && inspectCommand != "" {
+			return fmt.Errorf("give the command either after -- or with --command, not both")
+		}
+		return nil
+	},
+	RunE: runInspect,
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check tasks.json for broken dependencies and invalid data",
+	Long: `Lint the task list before a run.
+
+Errors (exit non-zero):
+  - Duplicate task IDs
+  - Dependencies on tasks that don't exist
+  - Dependency cycles
+  - Unknown statuses
+
+Warnings:
+  - Empty prompts
+  - Empty verification criteria
+
+All problems are reported, not just the first.`,
+	Example: `  autom8 validate`,
+	Args:    cobra.NoArgs,
+	RunE:    runValidate,
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a markdown report of tasks and outcomes",
+	Long: `Write a markdown report for reviews: completed tasks with their prompts,
+criteria, winning worktree, merged diff stats and iteration counts, followed
+by in-progress and pending work.
+
+--since limits the completed section to tasks finished in that window. The
+output only depends on the task list and git history, so successive reports
+diff cleanly.`,
+	Example: `  # Report on the last two weeks
+  autom8 report --since 14d --out report.md
+
+  # Everything, to stdout
+  autom8 report`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "Inspect and manage individual worktrees",
+	Long:  `Commands that operate on a single autom8 worktree.`,
+}
+
+var worktreesCmd = &cobra.Command{
+	Use:     "worktrees",
+	Aliases: []string{"wt"},
+	Short:   "List every worktree, one per line",
+	Long: `List the raw state of every worktree in .autom8/worktrees, independent of
+the task tree shown by status.
+
+Each line has the worktree name, task ID, branch, commits ahead of the
+default branch, whether it has uncommitted changes (modified/clean) and
+whether an agent is running in it (running/idle). The header is only printed
+to a terminal.`,
+	Example: `  autom8 worktrees
+
+  # Names of worktrees ready to accept
+  autom8 wt | awk '$4 > 0 && $6 == "idle" {print $1}'
+
+  # Machine-readable
+  autom8 worktrees --json
+
+  # What the agents are doing right now, with their PIDs
+  autom8 worktrees --running-only`,
+	Args: cobra.NoArgs,
+	RunE: runWorktrees,
+}
+
+var worktreeListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List every worktree, one per line (same as 'autom8 worktrees')",
+	Long:    worktreesCmd.Long,
+	Example: `  autom8 worktree list --running-only`,
+	Args:    cobra.NoArgs,
+	RunE:    runWorktrees,
+}
+
+var worktreeInfoCmd = &cobra.Command{
+	Use:   "info <worktree-name>",
+	Short: "Show everything known about a worktree",
+	Long: `Display a worktree's task, branch, run state, commits ahead of the
+default branch, its five most recent commits and a stat of uncommitted
+changes.`,
+	Example: `  autom8 worktree info task-123456789-1
+
+  # Machine-readable
+  autom8 worktree info task-123456789-1 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorktreeInfo,
+}
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate <worktree-name> [text]",
+	Short: "Add a review note to a worktree",
+	Long: `Attach a note to a worktree, e.g. while comparing implementations before
+converge. Notes are kept in .autom8/worktree_stats.json and shown under the
+worktree in status, describe and 'worktree info'.
+
+Without text, the worktree's notes are listed. 'converge --with-notes' gives
+them to the AI as hints from a human reviewer.`,
+	Example: `  autom8 annotate task-123456789-2 "cleaner error handling than -1"
+  autom8 annotate task-123456789-2
+  autom8 annotate task-123456789-2 --clear`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAnnotate,
+}
+
+var worktreeTouchCmd = &cobra.Command{
+	Use:   "touch <worktree-name>",
+	Short: "Mark a worktree as just used",
+	Long: `Set the worktree's last-accessed time in .autom8/worktree_stats.json to now.
+
+inspect and show do this automatically. Tools that work in a worktree
+directly can call it so the worktree is known to be in use.`,
+	Example: `  autom8 worktree touch task-123456789-1`,
 	Args:    cobra.ExactArgs(1),
-	RunE:    runInspect,
+	RunE:    runWorktreeTouch,
+}
+
+var worktreeRenameCmd = &cobra.Command{
+	Use:   "rename <old-name> <new-name>",
+	Short: "Rename a worktree and its branch",
+	Long: `Rename a worktree directory and its autom8/ branch, and move its logs,
+PID, stats and converge winner over to the new name.
+
+The new name must keep the task ID and instance suffix so the worktree stays
+linked to its task; add a descriptive prefix, like --label does.`,
+	Example: `  autom8 worktree rename task-123456789-1 login-fix-task-123456789-1`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runWorktreeRename,
+}
+
+var worktreeExportCmd = &cobra.Command{
+	Use:   "export <worktree-name> <output.tar.gz>",
+	Short: "Archive a worktree's files for sharing",
+	Long: `Write the files at the worktree's HEAD to a gzipped tarball, under a
+directory named after the worktree, with a MANIFEST.json describing the
+task (ID, prompt, criteria), branch and commits ahead of the default branch.
+
+Anyone can unpack and inspect it without access to the repository.
+Uncommitted changes are not included.`,
+	Example: `  autom8 worktree export task-123456789-1 login-page.tar.gz`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runWorktreeExport,
+}
+
+var worktreeDiffCmd = &cobra.Command{
+	Use:   "diff-to-main <worktree-name>",
+	Short: "Print a worktree's raw patch against the default branch, for scripts",
+	Long: `Print 'git diff <default branch>...HEAD' of the worktree to stdout and nothing else:
+no styling, header, stats or pager, whatever the terminal and git's color
+settings. Uncommitted changes are not included.
+
+This is the stable plumbing counterpart of 'autom8 show'; scripts should
+use it instead of parsing show's output.`,
+	Example: `  autom8 worktree diff-to-main task-123456789-1 > change.patch
+  autom8 worktree diff-to-main task-123456789-1 | git apply --check`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorktreeDiff,
 }
 
 var describeCmd = &cobra.Command{
@@ -192,10 +712,85 @@ Shows comprehensive task details including:
   - All verification criteria
   - Dependency information
   - Current status
-  - Associated worktrees and their state`,
-	Example: `  autom8 describe task-123456789`,
+  - Associated worktrees and their state
+
+With --logs, the end of each worktree's latest iteration log is shown too.`,
+	Example: `  autom8 describe task-123456789
+
+  # Include the last 40 lines of each worktree's latest agent output
+  autom8 describe task-123456789 --logs --log-lines 40`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribe,
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [worktree-name]",
+	Short: "List a worktree's iteration logs and page through one",
+	Long: `List the agent's iteration logs of a worktree with when each was written,
+its size, how long the iteration took and whether the agent reported
+TASK COMPLETE, then pick one to page through.
+
+--iteration N and --last skip the picker. Without a terminal, the list (or
+the chosen log) is printed as is. An iteration still running shows the
+output so far; follow it live with 'autom8 attach'.
+
+--prune --older-than removes the log directories of worktrees that no longer
+exist and were last written before the cutoff (--keep-logs archives them
+first). Logs of existing worktrees are removed with their task by 'prune' or
+'delete'.`,
+	Example: `  autom8 logs task-123456789-1
+
+  # Page through a specific iteration, or the newest one
+  autom8 logs task-123456789-1 --iteration 3
+  autom8 logs task-123456789-1 --last
+
+  # Is the agent converging or spinning?
+  autom8 logs task-123456789-1 --timings
+
+  # Remove logs of removed worktrees not written in the last 30 days
+  autom8 logs --prune --older-than 30d`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if pruneLogsFlag {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runLogs,
+}
+
+var attachCmd = &cobra.Command{
+	Use:   "attach <worktree-name>",
+	Short: "Follow the output of a running agent",
+	Long: `Stream the output of the agent working in a worktree, following it from
+one iteration to the next, until the agent finishes.
+
+Each iteration starts with a header line showing its number and how long the
+run has been going. Ctrl+C stops following; the agent keeps running. If no
+agent is running in the worktree, attach offers its last log instead.`,
+	Example: `  autom8 attach task-123456789-1`,
 	Args:    cobra.ExactArgs(1),
-	RunE:    runDescribe,
+	RunE:    runAttach,
+}
+
+var grepCmd = &cobra.Command{
+	Use:   "grep <pattern>",
+	Short: "Search task prompts and agent logs",
+	Long: `Search the tasks' prompts and verification criteria and every worktree's
+iteration logs for a regular expression (or a literal string with -F).
+
+Matches are grouped by task, then worktree and iteration, with line numbers
+and -C lines of context. Logs are read line by line, so multi-megabyte
+transcripts don't have to fit in memory; files that look binary are skipped.
+Logs of worktrees that have been removed are searched too.`,
+	Example: `  autom8 grep TestCheckout
+
+  # Literal text, only in the logs
+  autom8 grep -F "panic: runtime error [" --logs-only
+
+  # One task, ignoring case
+  autom8 grep -i "rate limit" --task task-123456789`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGrep,
 }
 
 var editCmd = &cobra.Command{
@@ -204,17 +799,38 @@ var editCmd = &cobra.Command{
 	Long: `Edit an existing task's prompt, verification criteria, or dependency.
 
 Starts an interactive editor to modify the task. All fields are optional -
-press Enter to keep the current value.`,
-	Example: `  autom8 edit task-123456789`,
-	Args:    cobra.ExactArgs(1),
-	RunE:    runEdit,
+press Enter to keep the current value.
+
+With any of the flags below, the task is changed directly without the
+editor, for scripts and CI.`,
+	Example: `  autom8 edit task-123456789
+
+  # Non-interactive
+  autom8 edit task-123456789 --prompt "Add a logout button to the header"
+  autom8 edit task-123456789 --add-criteria "Button is keyboard accessible"
+  autom8 edit task-123456789 --clear-depends-on`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEdit,
 }
 
 var pruneCmd = &cobra.Command{
 	Use:   "prune",
 	Short: "Delete all completed tasks",
-	Long:  `Remove all tasks with status "completed" from the task list.`,
-	RunE:  runPrune,
+	Long: `Remove all tasks with status "completed" from the task list, along with
+their worktrees, branches and logs. With --keep-logs the logs are archived
+to .autom8/logs/archive/ instead.
+
+A branch is only deleted once it is merged into the repository's default
+branch or the current one; unmerged branches, such as those of worktrees
+that lost a converge, are kept unless --force is given. Worktrees with
+local changes also need --force. A task whose worktrees could not all be
+removed stays in the list, so running prune again retries it.`,
+	Example: `  # See what would go first
+  autom8 prune --dry-run
+
+  # Also drop unmerged branches and worktrees with local changes
+  autom8 prune --force`,
+	RunE: runPrune,
 }
 
 var convergeCmd = &cobra.Command{
@@ -234,7 +850,19 @@ If no task ID is provided, all tasks with multiple worktrees will be evaluated.`
 
   # Converge and auto-merge the winner
   autom8 converge --merge
-  autom8 converge task-123456789 --merge`,
+  autom8 converge task-123456789 --merge
+
+  # Keep the AI's reasoning for auditing
+  autom8 converge task-123456789 --explain
+
+  # Only compare the 3 worktrees with the most commits
+  autom8 converge task-123456789 --top 3
+
+  # Leave out a worktree already known to be wrong
+  autom8 converge task-123456789 --exclude task-123456789-2
+
+  # Wait for agents that are still running, then converge
+  autom8 converge task-123456789 --wait --poll-interval 10s`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConverge,
 }
@@ -246,9 +874,19 @@ var showCmd = &cobra.Command{
 
 This shows the diff in a PR-style format, making it easy to review what
 changes an implementation has made.`,
-	Example: `  autom8 show task-123456789-1`,
-	Args:    cobra.ExactArgs(1),
-	RunE:    runShow,
+	Example: `  autom8 show task-123456789-1
+
+  # Also copy the diff to the clipboard
+  autom8 show task-123456789-1 --copy-to-clipboard
+
+  # More surrounding code in each hunk
+  autom8 show task-123456789-1 --context 10
+
+  # Generate a ready-to-paste PR description
+  autom8 show task-123456789-1 --format github
+  autom8 show task-123456789-1 --pr-body --copy-to-clipboard`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
 }
 
 var chatCmd = &cobra.Command{
@@ -258,8 +896,8 @@ var chatCmd = &cobra.Command{
 
 This command gathers context about the worktree including:
   - The original task prompt and verification criteria
-  - Commit history since branching from main
-  - Current diff from main
+  - Commit history since branching from the default branch
+  - Current diff from the default branch
 
 This context is passed to Claude via --system-prompt, allowing you to:
   - Ask questions about what was implemented
@@ -270,42 +908,451 @@ This context is passed to Claude via --system-prompt, allowing you to:
 	RunE:    runChat,
 }
 
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import tasks from an export file or external sources",
+	Long: `Import tasks from a file written by 'autom8 export', or from issue
+trackers with the github and gitlab subcommands.
+
+Imported tasks get new IDs, with dependencies between them remapped.
+Tasks whose prompt matches an existing task are skipped. Imported tasks
+always start as pending, and winners are dropped since their worktrees
+don't exist here.`,
+	Example: `  autom8 import tasks-export.json
+  autom8 import github --label ai-queue`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImportFile,
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tasks as JSON for another repository or machine",
+	Long:  `Write the task list as JSON to stdout, for 'autom8 import' elsewhere.`,
+	Example: `  autom8 export > tasks-export.json
+  autom8 export --pending-only > tasks-export.json`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a local JSON API for dashboards and editor integrations",
+	Long: `Serve a JSON API over HTTP for managing tasks and worktrees.
+
+Every request needs the token from server.token in .autom8/config.yaml (or
+$AUTOM8_SERVER_TOKEN), as "Authorization: Bearer <token>" or ?token=<token>.
+
+Endpoints:
+  GET    /api/tasks                      List tasks
+  POST   /api/tasks                      Create a task
+  GET    /api/tasks/{id}                 Show a task and its worktrees
+  PATCH  /api/tasks/{id}                 Edit prompt, criteria, dependency or status
+  DELETE /api/tasks/{id}                 Delete a task with its worktrees, branches and logs
+  POST   /api/tasks/{id}/implement       Start implement in the background
+  POST   /api/tasks/{id}/converge        Start converge in the background
+  GET    /api/worktrees                  List worktrees
+  POST   /api/worktrees/{name}/accept    Accept a worktree
+  GET    /api/worktrees/{name}/logs      Stream a worktree's logs (SSE)
+  GET    /api/status/stream              Stream tasks and worktrees (SSE)
+
+Each log event has the file name and its new text. When a file was rewritten
+(an iteration's log is replaced by its final transcript), reset is true and
+the text starts over from the beginning of the file.
+
+The server only listens on loopback addresses unless --allow-remote is given.`,
+	Example: `  autom8 serve
+  autom8 serve --addr 127.0.0.1:9000
+
+  curl -H "Authorization: Bearer $TOKEN" http://127.0.0.1:7337/api/tasks`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run an MCP server over stdio so agents can drive autom8",
+	Long: `Speak the Model Context Protocol over stdin/stdout, exposing autom8 as tools:
+
+  list_tasks, create_task      Query and add tasks
+  implement_task, converge_task  Start in the background and return a job_id
+  get_job                      Poll a job's state and output
+  accept_worktree              Merge a worktree
+  get_logs                     Read a worktree's agent logs
+
+Register it with an MCP client, e.g. for Claude Code:
+  claude mcp add autom8 -- autom8 mcp`,
+	Args: cobra.NoArgs,
+	RunE: runMCP,
+}
+
+var importGithubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Import open GitHub issues as tasks",
+	Long: `Create a task for each open GitHub issue matching the given filters.
+
+The issue title and body become the task prompt, and any checklist items
+("- [ ] ...") in the body become verification criteria. Each task remembers
+the issue it came from, so re-running the import skips issues that were
+already imported.
+
+Issues are fetched with the gh CLI when it is installed, otherwise with the
+GitHub API using forge.token or the GITHUB_TOKEN environment variable.`,
+	Example: `  # Import issues labeled ai-queue from the origin repository
+  autom8 import github --label ai-queue
+
+  # Import from a specific repository
+  autom8 import github --repo owner/name --label ai-queue`,
+	Args: cobra.NoArgs,
+	RunE: runImportGithub,
+}
+
+var importGitlabCmd = &cobra.Command{
+	Use:   "gitlab",
+	Short: "Import open GitLab issues as tasks",
+	Long: `Create a task for each open GitLab issue matching the given filters.
+
+Works like 'autom8 import github'. Issues are fetched with the GitLab API
+using forge.token or the GITLAB_TOKEN environment variable; set
+forge.base_url in .autom8/config.yaml for self-hosted instances.`,
+	Example: `  # Import issues labeled ai-queue from the origin project
+  autom8 import gitlab --label ai-queue
+
+  # Import from a specific project
+  autom8 import gitlab --repo group/project --label ai-queue`,
+	Args: cobra.NoArgs,
+	RunE: runImportGitlab,
+}
 
 // Flags
 var (
-	promptFlag    string
-	criteriaFlags []string
-	dependsOnFlag string
-	numInstances  int
-	maxIterations int
-	mergeFlag     bool
+	promptFlag       string
+	criteriaFlags    []string
+	dependsOnFlag    string
+	numInstances     int
+	maxIterations    int
+	mergeFlag        bool
+	labelFlag        string
+	copyFlag         bool
+	showFormat       string
+	prBodyFlag       bool
+	importLabels     []string
+	importRepo       string
+	importLimit      int
+	countsFlag       bool
+	prFlag           bool
+	pushFlag         bool
+	remoteFlag       string
+	noNotifyFlag     bool
+	intoFlag         string
+	selectWorktree   bool
+	autoImplement    bool
+	pruneSiblings    bool
+	logIteration     int
+	lastLogFlag      bool
+	diffContext      int
+	grepContext      int
+	yesFlag          bool
+	cascadeFlag      bool
+	promptAppend     string
+	notifyFlag       bool
+	waitFlag         bool
+	pollInterval     time.Duration
+	budgetUSD        float64
+	budgetTime       time.Duration
+	sinceFlag        string
+	outFlag          string
+	jsonFlag         bool
+	pendingOnly      bool
+	topFlag          int
+	legendFlag       bool
+	statusOutput     string
+	stdinPrompt      bool
+	keepBranchFlag   bool
+	keepWorktreeFlag bool
+	serveAddr        string
+	hideIDsFlag      bool
+	parentStrategy   string
+	explainFlag      bool
+	autoAcceptFlag   bool
+	allowRemoteFlag  bool
+	maxParallel      int
+	onExitFlag       string
+	implementLimit   int
+	allowFailures    int
+	verifyAfter      string
+	autoConverge     string
+	implementOutput  string
+	rateLimitBackoff time.Duration
+	startDepsFlag    bool
+	refreshFlag      bool
+	addCriteriaFlags []string
+	clearDependsOn   bool
+	inspectCommand   string
+	inspectQuiet     bool
+	logsFlag         bool
+	logLines         int
+	webhookURL       string
+	webhookHeaders   []string
+	atFlag           string
+	afterFlag        time.Duration
+	noWaitFlag       bool
+	epicFlag         string
+	keepLogsFlag     bool
+	killFlag         bool
+	pruneLogsFlag    bool
+	olderThanFlag    string
+	verifyCmdFlag    string
+	autoVerifyFlag   bool
+	verifyFlag       bool
+	maxRetries       int
+	timingsFlag      bool
+	excludeFlags     []string
+	noTemplateFlag   bool
+	batchFlag        string
+	taskFilter       string
+	logsOnlyFlag     bool
+	tasksOnlyFlag    bool
+	fixedStrings     bool
+	ignoreCase       bool
+	agentArgsFlag    string
+	allowDoneDep     bool
+	clearNotesFlag   bool
+	withNotesFlag    bool
+	keepConflicts    bool
+	jsonEvents       bool
+	pageSize         int
+	dependsOnLast    bool
+	runningOnly      bool
+	commitMessage    string
+	aiRetries        int
+	forceFlag        bool
+	dryRunFlag       bool
 )
 
 func init() {
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(implementCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(statusCmd)
+	statusCmd.AddCommand(statusSetCmd)
+	rootCmd.AddCommand(queueCmd)
+	queueCmd.AddCommand(queueMoveCmd)
+	queueCmd.AddCommand(queueCancelCmd)
 	rootCmd.AddCommand(acceptCmd)
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(inspectCmd)
 	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(grepCmd)
+	rootCmd.AddCommand(annotateCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(worktreeCmd)
+	rootCmd.AddCommand(worktreesCmd)
+	worktreeCmd.AddCommand(worktreeListCmd)
+	worktreeCmd.AddCommand(worktreeInfoCmd)
+	worktreeCmd.AddCommand(worktreeTouchCmd)
+	worktreeCmd.AddCommand(worktreeRenameCmd)
+	worktreeCmd.AddCommand(worktreeExportCmd)
+	worktreeCmd.AddCommand(worktreeDiffCmd)
 	rootCmd.AddCommand(editCmd)
 	rootCmd.AddCommand(pruneCmd)
 	rootCmd.AddCommand(convergeCmd)
 	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(chatCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(mcpCmd)
+	importCmd.AddCommand(importGithubCmd)
+	importCmd.AddCommand(importGitlabCmd)
+
+	// Complete task IDs and worktree names in the generated shell completions
+	for _, c := range []*cobra.Command{describeCmd, editCmd, deleteCmd, implementCmd, convergeCmd, statusSetCmd} {
+		c.ValidArgsFunction = completeTaskIDs
+	}
+	for _, c := range []*cobra.Command{showCmd, inspectCmd, attachCmd, logsCmd, chatCmd, queueMoveCmd, annotateCmd,
+		worktreeInfoCmd, worktreeTouchCmd, worktreeRenameCmd, worktreeExportCmd, worktreeDiffCmd} {
+		c.ValidArgsFunction = completeWorktreeNames
+	}
+	acceptCmd.ValidArgsFunction = completeWorktreesAndTasks
+	queueCancelCmd.ValidArgsFunction = completeWorktreesAndTasks
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7337", "Address to listen on")
+	serveCmd.Flags().BoolVar(&allowRemoteFlag, "allow-remote", false, "Allow listening on a non-loopback address")
+
+	rootCmd.PersistentFlags().BoolVar(&noNotifyFlag, "no-notify", false, "Don't send webhook or desktop notifications for this run")
 
 	// New command flags
 	newCmd.Flags().StringVarP(&promptFlag, "prompt", "p", "", "Task prompt (non-interactive mode)")
 	newCmd.Flags().StringArrayVarP(&criteriaFlags, "criteria", "c", []string{}, "Verification criteria (can be specified multiple times)")
 	newCmd.Flags().StringVarP(&dependsOnFlag, "depends-on", "d", "", "Task ID this depends on")
+	newCmd.Flags().BoolVar(&dependsOnLast, "depends-on-last", false, "Depend on the most recently created task")
+	newCmd.Flags().StringVar(&verifyCmdFlag, "verify-command", "", "Shell command that checks the work, run by implement --auto-verify and accept --verify")
+	newCmd.Flags().StringVar(&epicFlag, "epic", "", "Epic to group the task under")
+	newCmd.Flags().BoolVar(&allowDoneDep, "allow-completed-dep", false, "Allow depending on a task that is already completed")
+	newCmd.Flags().StringVar(&batchFlag, "batch", "", "Create every task defined in this YAML/JSON file")
+	newCmd.Flags().BoolVar(&autoImplement, "auto-implement", false, "Start implementing the task right after creating it")
+	newCmd.Flags().IntVarP(&numInstances, "instances", "n", 1, "With --auto-implement, number of parallel instances")
+	newCmd.Flags().IntVarP(&maxIterations, "max-iterations", "m", 0, "With --auto-implement, maximum iterations per worktree (0 = unlimited)")
+
+	editCmd.Flags().StringVarP(&promptFlag, "prompt", "p", "", "Replace the prompt")
+	editCmd.Flags().StringArrayVarP(&criteriaFlags, "criteria", "c", []string{}, "Replace the verification criteria (can be specified multiple times)")
+	editCmd.Flags().StringArrayVar(&addCriteriaFlags, "add-criteria", []string{}, "Append a verification criterion (can be specified multiple times)")
+	editCmd.Flags().StringVar(&verifyCmdFlag, "verify-command", "", "Set the verify command (\"\" removes it)")
+	editCmd.Flags().StringVarP(&dependsOnFlag, "depends-on", "d", "", "Set the task this depends on")
+	editCmd.Flags().BoolVar(&clearDependsOn, "clear-depends-on", false, "Make the task independent")
+	editCmd.Flags().BoolVar(&allowDoneDep, "allow-completed-dep", false, "Allow depending on a task that is already completed")
+	editCmd.Flags().StringVar(&epicFlag, "epic", "", "Move the task to this epic (\"\" removes it from its epic)")
+
+	describeCmd.Flags().BoolVar(&logsFlag, "logs", false, "Show the end of each worktree's latest iteration log")
+	describeCmd.Flags().IntVar(&logLines, "log-lines", 20, "Lines of each log shown with --logs (0 = the whole log)")
+
+	logsCmd.Flags().IntVar(&logIteration, "iteration", 0, "Show this iteration's log without the picker")
+	logsCmd.Flags().BoolVar(&lastLogFlag, "last", false, "Show the newest iteration log without the picker")
+	logsCmd.Flags().BoolVar(&timingsFlag, "timings", false, "Show how long each iteration took and whether they are getting faster")
+	logsCmd.Flags().BoolVar(&pruneLogsFlag, "prune", false, "Remove the logs of worktrees that no longer exist, with --older-than")
+	logsCmd.Flags().StringVar(&olderThanFlag, "older-than", "", "With --prune, only logs last written before this, e.g. 30d, 2w or 2026-01-31")
+	logsCmd.Flags().BoolVar(&keepLogsFlag, "keep-logs", false, "With --prune, archive the logs to .autom8/logs/archive/ before removing them")
+
+	// Annotate command flags
+	annotateCmd.Flags().BoolVar(&clearNotesFlag, "clear", false, "Remove the worktree's notes")
+
+	// Grep command flags
+	grepCmd.Flags().StringVar(&taskFilter, "task", "", "Only search this task and its worktrees' logs")
+	grepCmd.Flags().BoolVar(&logsOnlyFlag, "logs-only", false, "Only search iteration logs")
+	grepCmd.Flags().BoolVar(&tasksOnlyFlag, "tasks-only", false, "Only search task prompts and verification criteria")
+	grepCmd.Flags().BoolVarP(&fixedStrings, "fixed-strings", "F", false, "Treat the pattern as a literal string instead of a regular expression")
+	grepCmd.Flags().BoolVarP(&ignoreCase, "ignore-case", "i", false, "Match regardless of case")
+	grepCmd.Flags().IntVarP(&grepContext, "context", "C", 2, "Lines of context around each match")
+
+	inspectCmd.Flags().StringVar(&inspectCommand, "command", "", "Run this command in the worktree instead of an interactive shell")
+	inspectCmd.Flags().BoolVarP(&inspectQuiet, "quiet", "q", false, "Don't print the banner around the interactive shell")
 
 	// Implement command flags
 	implementCmd.Flags().IntVarP(&numInstances, "instances", "n", 1, "Number of parallel instances per task")
 	implementCmd.Flags().IntVarP(&maxIterations, "max-iterations", "m", 0, "Maximum iterations per worktree (0 = unlimited)")
+	implementCmd.Flags().DurationVar(&rateLimitBackoff, "rate-limit-backoff", 60*time.Second, "Wait before the first retry of an iteration that hit the API rate limit; doubles on each further retry, up to 10m")
+	implementCmd.Flags().IntVar(&maxRetries, "max-retries", 5, "Fail a worktree after this many rate-limit retries (0 = unlimited)")
+	implementCmd.Flags().StringVar(&implementOutput, "output-format", "plain", "How to print each worktree's result: plain, json (one object per line, other output on stderr) or table")
+	implementCmd.Flags().BoolVar(&jsonEvents, "json-events", false, "Stream progress as one JSON object per line on stdout as worktrees start, iterate and finish (other output on stderr)")
+	implementCmd.Flags().StringVar(&autoConverge, "auto-converge", "", "Converge each task once all its worktrees in this run finish; =merge also accepts the winner (default: implement.auto_converge)")
+	implementCmd.Flags().Lookup("auto-converge").NoOptDefVal = "on"
+	implementCmd.Flags().BoolVar(&autoVerifyFlag, "auto-verify", false, "When the agent reports TASK COMPLETE, run the task's verify command and keep iterating while it fails")
+	implementCmd.Flags().StringVar(&verifyAfter, "verify-after", "", "Shell command run in the worktree after each iteration; the worktree is complete once it exits zero")
+	implementCmd.Flags().IntVar(&allowFailures, "allow-failures", 0, "Exit zero as long as at most this many worktrees failed")
+	implementCmd.Flags().StringVar(&atFlag, "at", "", "Start at this local time (15:04, or 2006-01-02 15:04)")
+	implementCmd.Flags().DurationVar(&afterFlag, "after", 0, "Start after this long, e.g. 6h")
+	implementCmd.Flags().BoolVar(&noWaitFlag, "no-wait", false, "With --at/--after, record the schedule and exit; a running 'autom8 watch' starts the tasks")
+	implementCmd.Flags().StringVar(&epicFlag, "epic", "", "Implement only the pending tasks in this epic")
+	implementCmd.Flags().IntVar(&implementLimit, "limit", 0, "Implement only the first N pending tasks, oldest first (0 = all)")
+	implementCmd.Flags().StringVar(&labelFlag, "label", "", "Human-readable label to include in worktree and branch names")
+	implementCmd.Flags().BoolVar(&noTemplateFlag, "no-agent-template", false, "Send only the task prompt and criteria, without the implementer agent template (for debugging)")
+	implementCmd.Flags().StringVar(&promptAppend, "prompt-append", "", "Extra guidance appended to every task's prompt for this run only")
+	implementCmd.Flags().StringVar(&agentArgsFlag, "agent-args", "", "Extra arguments for the claude CLI, split like a shell would, e.g. \"--max-turns 5\"")
+	implementCmd.Flags().StringVar(&parentStrategy, "parent-strategy", "exponential", "How dependents branch from their parent: exponential (every parent instance), winner (the converge winner) or first (instance -1)")
+	implementCmd.Flags().BoolVar(&stdinPrompt, "stdin-prompt", false, "Implement a one-off prompt read from stdin in a single tmp- worktree, without saving a task")
+	implementCmd.Flags().BoolVar(&notifyFlag, "notify", false, "Show a desktop notification when the run finishes")
+	implementCmd.Flags().Float64Var(&budgetUSD, "budget-usd", 0, "Stop starting new iterations once this much has been spent across all agents (0 = unlimited; each iteration's log then appears when it ends)")
+	implementCmd.Flags().DurationVar(&budgetTime, "budget-time", 0, "Stop starting new iterations after this much wall-clock time (0 = unlimited)")
+
+	// Run command flags
+	runCmd.Flags().StringVarP(&promptFlag, "prompt", "p", "", "Task prompt")
+	runCmd.Flags().StringArrayVarP(&criteriaFlags, "criteria", "c", nil, "Verification criteria (can be specified multiple times)")
+	runCmd.Flags().IntVarP(&numInstances, "instances", "n", 1, "Number of parallel instances")
+	runCmd.Flags().IntVarP(&maxIterations, "max-iterations", "m", 0, "Maximum iterations per worktree (0 = unlimited)")
+	runCmd.Flags().BoolVar(&autoAcceptFlag, "auto-accept", false, "Accept the winner (or the single completed worktree) when done")
+
+	// Watch command flags
+	watchCmd.Flags().IntVarP(&numInstances, "instances", "n", 1, "Number of parallel instances per task")
+	watchCmd.Flags().IntVarP(&maxIterations, "max-iterations", "m", 0, "Maximum iterations per worktree (0 = unlimited)")
+	watchCmd.Flags().IntVar(&maxParallel, "max-parallel", 0, "Maximum worktrees implemented at once (0 = unlimited)")
+	watchCmd.Flags().StringVar(&onExitFlag, "on-exit", "kill", "What to do with running agents on SIGINT/SIGTERM: kill or detach")
+
+	// Status command flags
+	statusCmd.Flags().BoolVar(&countsFlag, "counts", false, "Show a one-line summary of task counts by status")
+	statusCmd.Flags().BoolVar(&hideIDsFlag, "hide-ids", false, "Don't print task ID lines (for demos and screen shares)")
+	statusCmd.Flags().BoolVar(&legendFlag, "legend", false, "Explain the status and worktree badges")
+	statusCmd.Flags().StringVar(&epicFlag, "epic", "", "Only show tasks in this epic")
+	statusCmd.Flags().StringVar(&statusOutput, "output", "text", "Output format: text or json (a flat task array when called as list)")
+	statusCmd.Flags().IntVar(&pageSize, "page-size", 0, "Show this many top-level tasks (with their dependents) at a time, pausing between pages (0 = no paging)")
+
+	// Show command flags
+	showCmd.Flags().BoolVar(&copyFlag, "copy-to-clipboard", false, "Also copy the diff to the system clipboard")
+	showCmd.Flags().StringVar(&showFormat, "format", "pretty", "Output format: pretty or github (markdown PR description)")
+	showCmd.Flags().BoolVar(&prBodyFlag, "pr-body", false, "Shorthand for --format github")
+	showCmd.Flags().IntVar(&diffContext, "context", 3, "Lines of context around each change (git diff -U)")
+
+	// Delete command flags
+	deleteCmd.Flags().BoolVar(&cascadeFlag, "cascade", false, "Also delete all tasks that depend on this task")
+	deleteCmd.Flags().BoolVar(&keepLogsFlag, "keep-logs", false, "Archive the tasks' logs to .autom8/logs/archive/ instead of deleting them")
+	deleteCmd.Flags().BoolVar(&killFlag, "kill", false, "Stop agents still running in the tasks' worktrees instead of leaving those worktrees behind")
+	deleteCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "With --cascade, don't ask for confirmation")
+
+	// Prune command flags
+	pruneCmd.Flags().BoolVar(&keepLogsFlag, "keep-logs", false, "Archive the tasks' logs to .autom8/logs/archive/ instead of deleting them")
+	pruneCmd.Flags().BoolVar(&forceFlag, "force", false, "Also remove worktrees with local changes and delete branches that were never merged (branches merged into the default branch are deleted with -D regardless)")
+	pruneCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "List what would be removed without removing anything")
+
+	// Export command flags
+	exportCmd.Flags().BoolVar(&pendingOnly, "pending-only", false, "Only export pending tasks")
+
+	// Import command flags
+	importGithubCmd.Flags().StringArrayVarP(&importLabels, "label", "l", []string{}, "Only import issues with this label (can be specified multiple times)")
+	importGithubCmd.Flags().StringVarP(&importRepo, "repo", "R", "", "Repository as owner/name (default: from the origin remote)")
+	importGithubCmd.Flags().IntVar(&importLimit, "limit", 100, "Maximum number of issues to fetch")
+	importGitlabCmd.Flags().StringArrayVarP(&importLabels, "label", "l", []string{}, "Only import issues with this label (can be specified multiple times)")
+	importGitlabCmd.Flags().StringVarP(&importRepo, "repo", "R", "", "Project as group/name (default: from the origin remote)")
+	importGitlabCmd.Flags().IntVar(&importLimit, "limit", 100, "Maximum number of issues to fetch")
+
+	// Accept command flags
+	acceptCmd.Flags().BoolVar(&prFlag, "pr", false, "Push the branch and open a pull/merge request instead of merging locally")
+	acceptCmd.Flags().BoolVar(&pushFlag, "push", false, "Push the current branch after merging")
+	acceptCmd.Flags().StringVar(&intoFlag, "into", "", "Merge into this branch instead of the current one (or target it with --pr)")
+	acceptCmd.Flags().BoolVar(&selectWorktree, "interactive-select", false, "Given a task ID with several worktrees and no winner, pick one from a list")
+	acceptCmd.Flags().BoolVar(&pruneSiblings, "prune-siblings", false, "After merging, remove the task's other worktrees and their branches (default: accept.prune_siblings)")
+	acceptCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Don't ask before pruning with --prune-siblings")
+	acceptCmd.Flags().BoolVar(&verifyFlag, "verify", false, "Run the task's verify command in the worktree first and don't merge if it fails")
+	acceptCmd.Flags().BoolVar(&keepBranchFlag, "keep-branch", false, "Don't delete the merged branch")
+	acceptCmd.Flags().BoolVar(&keepWorktreeFlag, "keep-worktree", false, "Don't remove the worktree (it is detached from the branch so the branch can be deleted)")
+	acceptCmd.Flags().StringVarP(&commitMessage, "commit-message", "m", "", "Message for the merge commit instead of the generated one (always creates a merge commit)")
+	acceptCmd.Flags().BoolVar(&keepConflicts, "keep-conflicts", false, "On conflicts, leave the merge in progress to resolve in place instead of aborting it")
+	acceptCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "POST the completed task to this URL after merging (default: webhook_url in config)")
+	acceptCmd.Flags().StringArrayVar(&webhookHeaders, "webhook-headers", []string{}, "Header for the completion webhook as Key:Value (repeatable)")
+	acceptCmd.Flags().BoolVar(&startDepsFlag, "start-dependents", false, "Implement the task's pending dependents from the merged branch (default: accept.start_dependents)")
+	acceptCmd.Flags().BoolVar(&cascadeFlag, "cascade", false, "With --start-dependents, also start dependents of tasks merged by that run (implement.auto_converge: merge)")
+	acceptCmd.Flags().IntVarP(&numInstances, "instances", "n", 1, "With --start-dependents, number of parallel instances per dependent")
+	acceptCmd.Flags().StringVar(&remoteFlag, "remote", "", "Remote to push to (default: accept.remote in config, or origin)")
+
+	// Report command flags
+	reportCmd.Flags().StringVar(&sinceFlag, "since", "", "Only include tasks completed in this window, e.g. 14d, 2w, 36h or 2026-01-31")
+	reportCmd.Flags().StringVarP(&outFlag, "out", "o", "", "Write the report to this file instead of stdout")
+
+	// Worktree command flags
+	worktreeInfoCmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the worktree info as JSON")
+	worktreesCmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the worktrees as a JSON array")
+	worktreesCmd.Flags().BoolVar(&runningOnly, "running-only", false, "Only list worktrees with a running agent, with its PID and how long it has run")
+	worktreeListCmd.Flags().AddFlagSet(worktreesCmd.Flags()) // An alias: same flags, same variables
 
 	// Converge command flags
 	convergeCmd.Flags().BoolVarP(&mergeFlag, "merge", "m", false, "Auto-merge the winning implementation")
+	convergeCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "With --merge, POST each completed task to this URL (default: webhook_url in config)")
+	convergeCmd.Flags().StringArrayVar(&webhookHeaders, "webhook-headers", []string{}, "Header for the completion webhook as Key:Value (repeatable)")
+	convergeCmd.Flags().BoolVar(&startDepsFlag, "start-dependents", false, "With --merge, implement the merged tasks' pending dependents (default: accept.start_dependents)")
+	convergeCmd.Flags().BoolVar(&cascadeFlag, "cascade", false, "With --start-dependents, also start dependents of tasks merged by that run")
+	convergeCmd.Flags().IntVarP(&numInstances, "instances", "n", 1, "With --start-dependents, number of parallel instances per dependent")
+	convergeCmd.Flags().BoolVar(&notifyFlag, "notify", false, "Show a desktop notification when convergence finishes")
+	convergeCmd.Flags().BoolVar(&explainFlag, "explain", false, "Save the AI's full reasoning to .autom8/convergence/<task-id>-<timestamp>.txt")
+	convergeCmd.Flags().BoolVar(&withNotesFlag, "with-notes", false, "Give the AI your 'autom8 annotate' notes on each worktree as hints")
+	convergeCmd.Flags().BoolVar(&refreshFlag, "refresh", false, "Re-run the analysis even if the worktrees are unchanged since the last converge")
+	convergeCmd.Flags().StringArrayVar(&excludeFlags, "exclude", []string{}, "Leave this worktree out of the comparison (can be specified multiple times)")
+	convergeCmd.Flags().IntVar(&aiRetries, "ai-retries", 1, "Ask the AI again up to N times when its response names no valid winner")
+	convergeCmd.Flags().IntVar(&topFlag, "top", 0, "Only compare the N worktrees with the most commits ahead (0 = all)")
+	convergeCmd.Flags().IntVar(&diffContext, "context", 3, "Lines of context around each change in the diffs given to the AI (git diff -U)")
+	convergeCmd.Flags().BoolVar(&waitFlag, "wait", false, "Wait for running agents to finish before analyzing")
+	convergeCmd.Flags().DurationVar(&pollInterval, "poll-interval", 5*time.Second, "How often --wait checks for running agents")
 }
 
 func main() {
@@ -314,13 +1361,138 @@ func main() {
 	}
 }
 
+// ErrNoGitRepo is returned when autom8 is run outside a git repository.
+var ErrNoGitRepo = errors.New("must be run inside a git repository\nRun 'git init' to create one here, or cd into an existing repository")
+
+// ErrTaskNotFound is returned when no task has the given ID.
+type ErrTaskNotFound struct {
+	ID string
+}
+
+func (e ErrTaskNotFound) Error() string {
+	return fmt.Sprintf("task '%s' not found\nRun 'autom8 status' to see task IDs", e.ID)
+}
+
+// ErrWorktreeNotFound is returned when no worktree has the given name.
+type ErrWorktreeNotFound struct {
+	Name string
+}
+
+func (e ErrWorktreeNotFound) Error() string {
+	return fmt.Sprintf("worktree '%s' not found\nRun 'autom8 status' to see available worktrees", e.Name)
+}
+
+// ErrHasDependents is returned when deleting a task that other tasks still
+// depend on.
+type ErrHasDependents struct {
+	TaskID     string
+	Dependents []string
+}
+
+func (e ErrHasDependents) Error() string {
+	msg := fmt.Sprintf("cannot delete task '%s' because these tasks depend on it:\n", e.TaskID)
+	for _, dep := range e.Dependents {
+		msg += fmt.Sprintf("  - %s\n", dep)
+	}
+	return msg + "Delete the dependent tasks first, or use --cascade to delete them too."
+}
+
+// ErrAgentNotFound is returned when the agent CLI is not installed.
+type ErrAgentNotFound struct {
+	Binary string
+}
+
+func (e ErrAgentNotFound) Error() string {
+	return fmt.Sprintf("%s was not found in PATH\nautom8 runs it as the coding agent; install it and try again", e.Binary)
+}
+
+// requireAgent checks that the agent CLI can be run before any work starts.
+func requireAgent() error {
+	if _, err := exec.LookPath("claude"); err != nil {
+		return ErrAgentNotFound{Binary: "claude"}
+	}
+	return nil
+}
+
+// requireGitRepo is the precondition shared by every command: all of them
+// work on the tasks and worktrees under the repository's .autom8 directory.
+func requireGitRepo(cmd *cobra.Command, args []string) error {
+	// Completion scripts are generated anywhere; completing task IDs and
+	// worktree names outside a repository just offers none
+	if cmd.Name() == "help" || cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd ||
+		(cmd.HasParent() && cmd.Parent().Name() == "completion") {
+		return nil
+	}
+	_, err := getGitRoot()
+	return err
+}
+
 func getGitRoot() (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("git was not found in PATH\nautom8 needs git to manage worktrees; install it and try again")
+	}
 	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("must be run inside a git repository")
+		return "", ErrNoGitRepo
 	}
-	return strings.TrimSpace(string(output)), nil
+	root := strings.TrimSpace(string(output))
+
+	// Inside one of autom8's worktrees (e.g. after 'autom8 inspect'), act on
+	// the main repository the worktree belongs to
+	if strings.Contains(filepath.ToSlash(root), "/"+autom8Dir+"/worktrees/") {
+		if main := mainRepoRoot(); main != "" && pathWithin(root, filepath.Join(main, autom8Dir, "worktrees")) {
+			return main, nil
+		}
+	}
+	return root, nil
+}
+
+// mainRepoRoot returns the top level of the main working tree of the current
+// repository, found from its common git dir, or "" for a bare repository.
+func mainRepoRoot() string {
+	output, err := exec.Command("git", "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return ""
+	}
+	commonDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(commonDir) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		commonDir = filepath.Join(cwd, commonDir)
+	}
+	if filepath.Base(commonDir) != ".git" {
+		return ""
+	}
+	return filepath.Dir(commonDir)
+}
+
+// pathWithin reports whether path is dir or inside it, after resolving
+// symlinks in both.
+func pathWithin(path, dir string) bool {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// leaveWorktree moves autom8 out of a worktree it is about to remove, so git
+// never runs from a deleted directory, and warns that the shell autom8 was
+// started from is left there.
+func leaveWorktree(worktreePath, gitRoot string) {
+	cwd, err := os.Getwd()
+	if err != nil || !pathWithin(cwd, worktreePath) {
+		return
+	}
+	fmt.Printf("%s your shell is inside %s, which is being removed; run 'cd %s' afterwards\n",
+		errorStyle.Render("Warning:"), worktreePath, gitRoot)
+	os.Chdir(gitRoot)
 }
 
 func getAutom8Dir() (string, error) {
@@ -352,6 +1524,132 @@ func loadAgentTemplate(name string) (string, error) {
 	return string(data), nil
 }
 
+// loadConfig reads .autom8/config.yaml. A missing file yields the zero Config.
+func loadConfig() (Config, error) {
+	var cfg Config
+
+	dir, err := getAutom8Dir()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, configFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing %s: %w", configFile, err)
+	}
+	return cfg, nil
+}
+
+// resolveMCPConfig returns the absolute path of the configured MCP server config,
+// or an empty string if none is configured. A configured but missing file is an error.
+func resolveMCPConfig(cfg Config, gitRoot string) (string, error) {
+	if cfg.Agent.MCPConfig == "" {
+		return "", nil
+	}
+
+	path := cfg.Agent.MCPConfig
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(gitRoot, path)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("MCP config '%s' not found (set by agent.mcp_config in %s/%s)", cfg.Agent.MCPConfig, autom8Dir, configFile)
+	}
+	return path, nil
+}
+
+// loadMCPConfig loads the config and resolves the MCP config path in one step.
+func loadMCPConfig(gitRoot string) (string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", fmt.Errorf("error loading config: %w", err)
+	}
+	return resolveMCPConfig(cfg, gitRoot)
+}
+
+// withMCPConfig appends the --mcp-config flag to claude arguments when configured.
+func withMCPConfig(args []string, mcpConfig string) []string {
+	if mcpConfig == "" {
+		return args
+	}
+	return append(args, "--mcp-config", mcpConfig)
+}
+
+// parseAgentArgs splits --agent-args into the arguments added to every
+// implement iteration's claude command.
+func parseAgentArgs() ([]string, error) {
+	args, err := splitShellArgs(agentArgsFlag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --agent-args: %w", err)
+	}
+	return args, nil
+}
+
+// splitShellArgs splits s into arguments the way a POSIX shell would,
+// without any expansion: whitespace separates arguments, single quotes keep
+// their contents as is, double quotes allow \" \\ \$ and \` escapes, and a
+// backslash outside quotes escapes the next character.
+func splitShellArgs(s string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`", runes[i+1]) {
+				i++
+				arg.WriteRune(runes[i])
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\\':
+			if i+1 == len(runes) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			i++
+			arg.WriteRune(runes[i])
+			inArg = true
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
 func loadTasks() ([]Task, error) {
 	dir, err := getAutom8Dir()
 	if err != nil {
@@ -389,74 +1687,469 @@ func saveTasks(tasks []Task) error {
 		return err
 	}
 
-	return os.WriteFile(tasksPath, data, 0644)
+	// Write via rename so a concurrent reader (e.g. watch) never sees a partial file
+	tmpPath := tasksPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, tasksPath)
 }
 
-// PID tracking for worktrees
-func loadPids() (map[string]int, error) {
-	dir, err := getAutom8Dir()
-	if err != nil {
-		return make(map[string]int), nil
-	}
+// lockTasks takes an exclusive lock on tasks.json so that concurrent autom8
+// processes (e.g. watch and new) don't lose each other's writes.
+func lockTasks() (func(), error) {
+	return lockAutom8File(tasksLockFile, "tasks")
+}
 
-	pidsPath := filepath.Join(dir, pidsFile)
-	data, err := os.ReadFile(pidsPath)
+// lockAutom8File takes the named lock file in .autom8, waiting up to 10s
+// for another process to release it. A lock left behind by a process that
+// is no longer running is taken over.
+func lockAutom8File(name, what string) (func(), error) {
+	dir, err := ensureAutom8Dir()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return make(map[string]int), nil
-		}
 		return nil, err
 	}
 
-	var pids map[string]int
-	if err := json.Unmarshal(data, &pids); err != nil {
-		return make(map[string]int), nil
+	lockPath := filepath.Join(dir, name)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d", os.Getpid())
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		data, _ := os.ReadFile(lockPath)
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && !isProcessRunning(pid) {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s are locked by another autom8 process (remove %s if it is stale)", what, lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
 	}
-	return pids, nil
 }
 
-func savePids(pids map[string]int) error {
-	dir, err := ensureAutom8Dir()
+// updateTasks loads, modifies and saves tasks while holding the tasks lock.
+// If fn returns nil tasks the file is left untouched.
+func updateTasks(fn func(tasks []Task) ([]Task, error)) error {
+	unlock, err := lockTasks()
 	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	pidsPath := filepath.Join(dir, pidsFile)
-	data, err := json.MarshalIndent(pids, "", "  ")
+	tasks, err := loadTasks()
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(pidsPath, data, 0644)
+	tasks, err = fn(tasks)
+	if err != nil || tasks == nil {
+		return err
+	}
+	return saveTasks(tasks)
 }
 
-func savePid(worktreeName string, pid int) {
-	pids, _ := loadPids()
+// queuedJob is a worktree waiting for, or holding, one of the agent slots
+// limited by queue.max_agents. queue.json keeps jobs in dispatch order.
+type queuedJob struct {
+	Worktree  string     `json:"worktree"`
+	TaskID    string     `json:"task_id"`
+	PID       int        `json:"pid"` // autom8 process that will run the agent
+	QueuedAt  time.Time  `json:"queued_at"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+}
+
+// errJobCancelled is returned by waitForSlot when the job was removed with
+// 'autom8 queue cancel'
+var errJobCancelled = errors.New("removed from the queue")
+
+func loadQueue() ([]queuedJob, error) {
+	dir, err := getAutom8Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, queueFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []queuedJob{}, nil
+		}
+		return nil, err
+	}
+
+	var jobs []queuedJob
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", queueFile, err)
+	}
+
+	// Jobs whose process exited (e.g. on Ctrl+C) will never run or finish
+	live := jobs[:0]
+	for _, job := range jobs {
+		if isProcessRunning(job.PID) {
+			live = append(live, job)
+		}
+	}
+	return live, nil
+}
+
+func saveQueue(jobs []queuedJob) error {
+	dir, err := ensureAutom8Dir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	queuePath := filepath.Join(dir, queueFile)
+	tmpPath := queuePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, queuePath)
+}
+
+// updateQueue loads, modifies and saves the queue while holding its lock.
+// If fn returns nil jobs the file is left untouched.
+func updateQueue(fn func(jobs []queuedJob) ([]queuedJob, error)) error {
+	unlock, err := lockAutom8File(queueLockFile, "the queue")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	jobs, err := loadQueue()
+	if err != nil {
+		return err
+	}
+	jobs, err = fn(jobs)
+	if err != nil || jobs == nil {
+		return err
+	}
+	return saveQueue(jobs)
+}
+
+// enqueueWorktrees appends worktrees to the queue, owned by this process.
+// instanceIDs and taskIDs are parallel.
+func enqueueWorktrees(instanceIDs, taskIDs []string) error {
+	return updateQueue(func(jobs []queuedJob) ([]queuedJob, error) {
+		for i, name := range instanceIDs {
+			jobs = append(jobs, queuedJob{Worktree: name, TaskID: taskIDs[i], PID: os.Getpid(), QueuedAt: time.Now()})
+		}
+		return jobs, nil
+	})
+}
+
+// dequeueWorktrees removes worktrees from the queue, started or not.
+func dequeueWorktrees(names ...string) error {
+	remove := make(map[string]bool)
+	for _, name := range names {
+		remove[name] = true
+	}
+	return updateQueue(func(jobs []queuedJob) ([]queuedJob, error) {
+		kept := jobs[:0]
+		for _, job := range jobs {
+			if !remove[job.Worktree] {
+				kept = append(kept, job)
+			}
+		}
+		return kept, nil
+	})
+}
+
+// waitForSlot blocks until the queued worktree may start its agent: it must
+// be among the first queued jobs that fit in the free slots. The limit is
+// re-read from the config on every poll, so it can be changed while
+// watch is running. onWait is called with the number of jobs ahead.
+func waitForSlot(ctx context.Context, name string, onWait func(ahead int)) error {
+	for {
+		maxAgents := 0
+		if cfg, err := loadConfig(); err == nil {
+			maxAgents = cfg.Queue.MaxAgents
+		}
+
+		found, started, ahead := false, false, 0
+		err := updateQueue(func(jobs []queuedJob) ([]queuedJob, error) {
+			running := 0
+			for _, job := range jobs {
+				if job.StartedAt != nil {
+					running++
+				}
+			}
+			for i, job := range jobs {
+				if job.Worktree != name {
+					if job.StartedAt == nil {
+						ahead++
+					}
+					continue
+				}
+				found = true
+				if maxAgents > 0 && ahead >= maxAgents-running {
+					return nil, nil
+				}
+				now := time.Now()
+				jobs[i].StartedAt = &now
+				started = true
+				return jobs, nil
+			}
+			return nil, nil
+		})
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errJobCancelled
+		}
+		if started {
+			return nil
+		}
+		if onWait != nil {
+			onWait(ahead)
+		}
+
+		select {
+		case <-ctx.Done():
+			dequeueWorktrees(name)
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// PID tracking for worktrees
+func loadPids() (map[string]int, error) {
+	dir, err := getAutom8Dir()
+	if err != nil {
+		return make(map[string]int), nil
+	}
+
+	pidsPath := filepath.Join(dir, pidsFile)
+	data, err := os.ReadFile(pidsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]int), nil
+		}
+		return nil, err
+	}
+
+	var pids map[string]int
+	if err := json.Unmarshal(data, &pids); err != nil {
+		return make(map[string]int), nil
+	}
+	return pids, nil
+}
+
+func savePids(pids map[string]int) error {
+	dir, err := ensureAutom8Dir()
+	if err != nil {
+		return err
+	}
+
+	pidsPath := filepath.Join(dir, pidsFile)
+	data, err := json.MarshalIndent(pids, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(pidsPath, data, 0644)
+}
+
+// pidsMu serializes updates to pids.json from concurrent worktree goroutines
+var pidsMu sync.Mutex
+
+func savePid(worktreeName string, pid int) {
+	pidsMu.Lock()
+	defer pidsMu.Unlock()
+
+	pids, err := loadPids()
+	if err != nil {
+		pids = make(map[string]int)
+	}
 	pids[worktreeName] = pid
 	savePids(pids)
 }
 
-func isProcessRunning(pid int) bool {
-	if pid <= 0 {
-		return false
+func clearPid(worktreeName string) {
+	pidsMu.Lock()
+	defer pidsMu.Unlock()
+
+	pids, err := loadPids()
+	if err != nil {
+		return
+	}
+	if _, ok := pids[worktreeName]; ok {
+		delete(pids, worktreeName)
+		savePids(pids)
+	}
+}
+
+// worktreeStats records per-worktree usage and notes that git doesn't track
+type worktreeStats struct {
+	LastAccessedAt time.Time      `json:"last_accessed_at,omitzero"`
+	Outcome        string         `json:"outcome,omitempty"`       // Result of the last implement run: completed, failed or stopped
+	FailureClass   string         `json:"failure_class,omitempty"` // Kind of failure of the last run, see classifyFailure
+	FinishedAt     time.Time      `json:"finished_at,omitzero"`
+	Notes          []worktreeNote `json:"notes,omitempty"` // From 'autom8 annotate'
+}
+
+// worktreeNote is a reviewer's note on a worktree.
+type worktreeNote struct {
+	Text string    `json:"text"`
+	At   time.Time `json:"at"`
+}
+
+// statsMu serializes updates to worktree_stats.json from concurrent worktree goroutines
+var statsMu sync.Mutex
+
+func loadWorktreeStats() (map[string]worktreeStats, error) {
+	dir, err := getAutom8Dir()
+	if err != nil {
+		return nil, err
 	}
-	process, err := os.FindProcess(pid)
+
+	data, err := os.ReadFile(filepath.Join(dir, statsFile))
 	if err != nil {
-		return false
+		if os.IsNotExist(err) {
+			return make(map[string]worktreeStats), nil
+		}
+		return nil, err
 	}
-	// On Unix, FindProcess always succeeds, so we need to send signal 0 to check
-	err = process.Signal(syscall.Signal(0))
-	return err == nil
+
+	stats := make(map[string]worktreeStats)
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", statsFile, err)
+	}
+	return stats, nil
 }
 
-func runFeature(cmd *cobra.Command, args []string) error {
-	// Check git repo first
-	if _, err := getGitRoot(); err != nil {
+// touchWorktree sets a worktree's last-accessed time to now.
+func touchWorktree(worktreeName string) error {
+	return updateWorktreeStats(worktreeName, func(s *worktreeStats) {
+		s.LastAccessedAt = time.Now()
+	})
+}
+
+// recordWorktreeOutcome saves the result event of an implement run.
+func recordWorktreeOutcome(worktreeName, event, class string) {
+	updateWorktreeStats(worktreeName, func(s *worktreeStats) {
+		s.Outcome = strings.TrimPrefix(event, "worktree_")
+		s.FailureClass = class
+		s.FinishedAt = time.Now()
+	})
+}
+
+func updateWorktreeStats(worktreeName string, fn func(s *worktreeStats)) error {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	stats, err := loadWorktreeStats()
+	if err != nil {
+		return err
+	}
+	s := stats[worktreeName]
+	fn(&s)
+	stats[worktreeName] = s
+	return saveWorktreeStats(stats)
+}
+
+// renameWorktreeStats moves a worktree's stats to its new name.
+func renameWorktreeStats(oldName, newName string) error {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	stats, err := loadWorktreeStats()
+	if err != nil {
+		return err
+	}
+	s, ok := stats[oldName]
+	if !ok {
+		return nil
+	}
+	delete(stats, oldName)
+	stats[newName] = s
+	return saveWorktreeStats(stats)
+}
+
+func saveWorktreeStats(stats map[string]worktreeStats) error {
+	dir, err := ensureAutom8Dir()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
 		return err
 	}
+	return os.WriteFile(filepath.Join(dir, statsFile), data, 0644)
+}
+
+// worktreeNamePattern matches worktree names of the form
+// [{label}-]task-{timestamp}-{instance}[-{instance}...], or tmp-{timestamp}
+// for temporary tasks
+var worktreeNamePattern = regexp.MustCompile(`((?:task|tmp)-\d+)(?:-\d+)+$`)
+
+const (
+	tempTaskPrefix = "tmp-"      // Task ID prefix for implement --stdin-prompt runs
+	tempTaskFile   = "task.json" // Temporary task, kept in the worktree's logs dir
+)
+
+// branchLabelPattern restricts implement labels to characters that are safe in branch names
+var branchLabelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// parseTaskIDFromWorktreeName extracts the task ID from a worktree name,
+// ignoring any label prefix and all instance suffixes.
+func parseTaskIDFromWorktreeName(worktreeName string) string {
+	if m := worktreeNamePattern.FindStringSubmatch(worktreeName); m != nil {
+		return m[1]
+	}
+	// Unknown format: fall back to stripping the last -{instance} suffix
+	if lastDash := strings.LastIndex(worktreeName, "-"); lastDash > 0 {
+		return worktreeName[:lastDash]
+	}
+	return worktreeName
+}
+
+// worktreeInstanceID builds the worktree/branch name for a task instance.
+func worktreeInstanceID(label, taskID, suffix string) string {
+	if label != "" {
+		return fmt.Sprintf("%s-%s%s", label, taskID, suffix)
+	}
+	return taskID + suffix
+}
+
+func runFeature(cmd *cobra.Command, args []string) error {
+	if batchFlag != "" {
+		if promptFlag != "" || len(criteriaFlags) > 0 || dependsOnFlag != "" || dependsOnLast || verifyCmdFlag != "" {
+			return fmt.Errorf("--batch reads tasks from the file; drop -p, -c, -d, --depends-on-last and --verify-command")
+		}
+		return runFeatureBatch(batchFlag)
+	}
+
+	if dependsOnLast {
+		if dependsOnFlag != "" {
+			return fmt.Errorf("use either --depends-on or --depends-on-last, not both")
+		}
+		tasks, err := loadTasks()
+		if err != nil {
+			return fmt.Errorf("error loading tasks: %w", err)
+		}
+		last := lastCreatedTask(tasks)
+		if last == nil {
+			return fmt.Errorf("--depends-on-last: there are no tasks yet")
+		}
+		dependsOnFlag = last.ID
+	}
 
 	var prompt string
 	var criteria []string
 	var dependsOn string
+	epic := epicFlag
 
 	if promptFlag != "" {
 		// Non-interactive mode
@@ -464,8 +2157,18 @@ func runFeature(cmd *cobra.Command, args []string) error {
 		criteria = criteriaFlags
 		dependsOn = dependsOnFlag
 	} else {
-		// Interactive mode with huh
+		if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+			return fmt.Errorf("no terminal for the interactive form; create the task with flags instead:\n" +
+				"  -p, --prompt <text>        Task prompt (required)\n" +
+				"  -c, --criteria <text>      Verification criterion (repeatable)\n" +
+				"  -d, --depends-on <task-id> Task this depends on\n" +
+				"      --verify-command <cmd> Shell command that checks the work\n" +
+				"      --epic <name>          Epic to group the task under")
+		}
+
+		// Interactive mode with huh; -d or --depends-on-last preselects the dependency
 		var criteriaInput string
+		dependsOn = dependsOnFlag
 
 		// Load existing tasks for dependency selection
 		existingTasks, _ := loadTasks()
@@ -507,6 +2210,12 @@ func runFeature(cmd *cobra.Command, args []string) error {
 					Options(dependsOnOptions...).
 					Value(&dependsOn),
 			),
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Epic").
+					Description("Group related tasks under a name (optional)").
+					Value(&epic),
+			),
 		).WithTheme(huh.ThemeDracula())
 
 		err := form.Run()
@@ -538,1273 +2247,9436 @@ func runFeature(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error loading tasks: %w", err)
 	}
 
-	// Validate dependency exists if specified
-	if dependsOn != "" {
-		found := false
-		for _, t := range tasks {
-			if t.ID == dependsOn {
-				found = true
-				break
+	if dup, similarity := findDuplicateTask(tasks, prompt); dup != nil {
+		fmt.Printf("%s this prompt is %.0f%% similar to existing task %s:\n", errorStyle.Render("Warning:"), similarity*100, idStyle.Render(dup.ID))
+		fmt.Printf("  %s\n", truncate(dup.Prompt, 70))
+
+		if isTerminal(os.Stdin) {
+			var confirmed bool
+			err := huh.NewConfirm().
+				Title("Create the task anyway?").
+				Affirmative("Create").
+				Negative("Cancel").
+				Value(&confirmed).
+				WithTheme(huh.ThemeDracula()).
+				Run()
+			if err != nil && err != huh.ErrUserAborted {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted.")
+				return nil
 			}
 		}
-		if !found {
-			return fmt.Errorf("dependency task '%s' not found", dependsOn)
-		}
+	}
+
+	if err := checkDependency(tasks, "", dependsOn, allowDoneDep); err != nil {
+		return err
 	}
 
 	task := Task{
 		ID:                   fmt.Sprintf("task-%d", time.Now().UnixNano()),
 		Prompt:               prompt,
 		VerificationCriteria: criteria,
+		VerifyCommand:        strings.TrimSpace(verifyCmdFlag),
 		DependsOn:            dependsOn,
+		Epic:                 strings.TrimSpace(epic),
 		CreatedAt:            time.Now(),
 		Status:               "pending",
 	}
 
-	tasks = append(tasks, task)
-
-	if err := saveTasks(tasks); err != nil {
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		return append(tasks, task), nil
+	})
+	if err != nil {
 		return fmt.Errorf("error saving task: %w", err)
 	}
 
 	fmt.Println()
 	fmt.Println(successStyle.Render("Task created successfully!"))
 	fmt.Printf("  %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
-	return nil
-}
+	if task.DependsOn != "" {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Depends on:"), idStyle.Render(task.DependsOn))
+	}
 
-// WorktreeInfo holds information about a worktree's status
-type WorktreeInfo struct {
-	Name         string
-	Path         string
-	Branch       string
-	CommitsAhead string
-	HasChanges   bool
-	IsRunning    bool
+	if autoImplement {
+		fmt.Println()
+		outcomes, err := implementTasks([]string{task.ID}, "")
+		if err != nil || outcomes == nil {
+			return err
+		}
+		return outcomes.checkFailures(allowFailures)
+	}
+	return nil
 }
 
-func getWorktreeInfo(worktreesDir, worktreeName string, pids map[string]int) WorktreeInfo {
-	worktreePath := filepath.Join(worktreesDir, worktreeName)
-	info := WorktreeInfo{
-		Name: worktreeName,
-		Path: worktreePath,
+// lastCreatedTask returns the task created most recently, or nil if there
+// are none.
+func lastCreatedTask(tasks []Task) *Task {
+	var last *Task
+	for i := range tasks {
+		if last == nil || tasks[i].CreatedAt.After(last.CreatedAt) {
+			last = &tasks[i]
+		}
 	}
+	return last
+}
 
-	// Get the branch name
-	branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
-	if branchOutput, err := branchCmd.Output(); err == nil {
-		info.Branch = strings.TrimSpace(string(branchOutput))
-	} else {
-		info.Branch = "unknown"
-	}
+// batchTask is one entry of a 'new --batch' file. Key is a name local to the
+// file that other entries can use in depends_on instead of a task ID.
+type batchTask struct {
+	Key           string   `yaml:"key"`
+	Prompt        string   `yaml:"prompt"`
+	Criteria      []string `yaml:"criteria"`
+	VerifyCommand string   `yaml:"verify_command"`
+	Epic          string   `yaml:"epic"`
+	DependsOn     string   `yaml:"depends_on"`
+}
 
-	// Check if there are any git changes
-	statusCmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
-	if statusOutput, err := statusCmd.Output(); err == nil {
-		info.HasChanges = len(strings.TrimSpace(string(statusOutput))) > 0
+// loadBatchFile parses a 'new --batch' file: a YAML (or JSON) list of
+// entries, optionally under a top-level tasks key. Unknown keys are errors so
+// a typo can't silently drop a field.
+func loadBatchFile(path string) ([]batchTask, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
 	}
 
-	// Check how many commits are ahead
-	aheadCmd := exec.Command("git", "-C", worktreePath, "rev-list", "--count", "HEAD", "^main")
-	if aheadOutput, err := aheadCmd.Output(); err == nil {
-		info.CommitsAhead = strings.TrimSpace(string(aheadOutput))
-	} else {
-		info.CommitsAhead = "0"
+	var entries []batchTask
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&entries); err != nil {
+		var wrapped struct {
+			Tasks []batchTask `yaml:"tasks"`
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if dec.Decode(&wrapped) != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		entries = wrapped.Tasks
 	}
-
-	// Check if the tracked process is still running
-	if pid, ok := pids[worktreeName]; ok {
-		info.IsRunning = isProcessRunning(pid)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s defines no tasks", path)
 	}
-
-	return info
+	return entries, nil
 }
 
-func runStatus(cmd *cobra.Command, args []string) error {
-	if _, err := getGitRoot(); err != nil {
-		return err
+// validateBatch checks a batch against the existing tasks: every entry needs
+// a prompt, keys must be unique, depends_on must name a key in the file or an
+// existing task, and keys must not depend on each other in a cycle.
+func validateBatch(entries []batchTask, tasks []Task) error {
+	byKey := make(map[string]int)
+	for i, e := range entries {
+		if strings.TrimSpace(e.Prompt) == "" {
+			return fmt.Errorf("entry %d: prompt is required", i+1)
+		}
+		if e.Key == "" {
+			continue
+		}
+		if first, ok := byKey[e.Key]; ok {
+			return fmt.Errorf("entry %d: key '%s' is already used by entry %d", i+1, e.Key, first+1)
+		}
+		byKey[e.Key] = i
 	}
 
-	tasks, err := loadTasks()
-	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
-	}
+	for i, e := range entries {
+		if e.DependsOn == "" {
+			continue
+		}
+		if _, ok := byKey[e.DependsOn]; !ok {
+			if findTask(tasks, e.DependsOn) == nil {
+				return fmt.Errorf("entry %d: depends_on '%s' is neither a key in the file nor an existing task", i+1, e.DependsOn)
+			}
+			if err := checkDependency(tasks, "", e.DependsOn, allowDoneDep); err != nil {
+				return fmt.Errorf("entry %d: %w", i+1, err)
+			}
+		}
 
-	// Get worktrees and PIDs
-	autom8Path, _ := getAutom8Dir()
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	worktreesByTask := make(map[string][]WorktreeInfo)
-	pids, _ := loadPids()
+		if e.Key == "" {
+			continue // nothing can depend on it, so it can't close a cycle
+		}
 
-	if entries, err := os.ReadDir(worktreesDir); err == nil {
-		for _, entry := range entries {
-			if !entry.IsDir() {
-				continue
+		// Each entry has one parent, so a cycle shows up as a key seen twice
+		// while walking up the chain
+		chain := []string{e.Key}
+		seen := map[int]bool{i: true}
+		for next, ok := byKey[entries[i].DependsOn]; ok; next, ok = byKey[entries[next].DependsOn] {
+			chain = append(chain, entries[next].Key)
+			if seen[next] {
+				return fmt.Errorf("entry %d: dependency cycle %s", i+1, strings.Join(chain, " -> "))
 			}
-			worktreeName := entry.Name()
-			// Extract task ID: task-{timestamp}-{instance} -> task-{timestamp}
-			taskID := worktreeName
-			if lastDash := strings.LastIndex(worktreeName, "-"); lastDash > 0 {
-				taskID = worktreeName[:lastDash]
-			}
-			info := getWorktreeInfo(worktreesDir, worktreeName, pids)
-			worktreesByTask[taskID] = append(worktreesByTask[taskID], info)
+			seen[next] = true
 		}
 	}
+	return nil
+}
 
-	if len(tasks) == 0 {
-		fmt.Println(subtitleStyle.Render("No tasks found. Use 'autom8 new' to create one."))
-		return nil
+// runFeatureBatch creates every task in a 'new --batch' file, mapping keys in
+// depends_on to the new task IDs. Nothing is created unless the whole file
+// is valid.
+func runFeatureBatch(path string) error {
+	entries, err := loadBatchFile(path)
+	if err != nil {
+		return err
 	}
 
-	// Build dependency tree
-	taskMap := make(map[string]Task)
-	childrenMap := make(map[string][]string) // parent ID -> child IDs
-	var rootTasks []string
+	var created []Task
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		if err := validateBatch(entries, tasks); err != nil {
+			return nil, fmt.Errorf("%s: %w\nNo tasks were created", path, err)
+		}
 
-	for _, t := range tasks {
-		taskMap[t.ID] = t
-		if t.DependsOn == "" {
-			rootTasks = append(rootTasks, t.ID)
-		} else {
-			childrenMap[t.DependsOn] = append(childrenMap[t.DependsOn], t.ID)
+		idByKey := make(map[string]string)
+		for _, e := range entries {
+			task := Task{
+				ID:                   newTaskID(append(tasks, created...)),
+				Prompt:               strings.TrimSpace(e.Prompt),
+				VerificationCriteria: e.Criteria,
+				VerifyCommand:        strings.TrimSpace(e.VerifyCommand),
+				DependsOn:            e.DependsOn,
+				Epic:                 strings.TrimSpace(e.Epic),
+				CreatedAt:            time.Now(),
+				Status:               "pending",
+			}
+			if task.Epic == "" {
+				task.Epic = strings.TrimSpace(epicFlag)
+			}
+			if e.Key != "" {
+				idByKey[e.Key] = task.ID
+			}
+			created = append(created, task)
+		}
+		for i := range created {
+			if id, ok := idByKey[created[i].DependsOn]; ok {
+				created[i].DependsOn = id
+			}
 		}
+		return append(tasks, created...), nil
+	})
+	if err != nil {
+		return err
 	}
 
-	fmt.Println(titleStyle.Render("Status"))
+	fmt.Println(titleStyle.Render("Creating Tasks"))
 	fmt.Println()
-
-	// Print tree recursively
-	var printTask func(taskID string, prefix string, isLast bool)
-	printTask = func(taskID string, prefix string, isLast bool) {
-		task := taskMap[taskID]
-
-		// Tree branch characters
-		branch := "├── "
-		if isLast {
-			branch = "└── "
+	for i, t := range created {
+		label := successStyle.Render("[created]")
+		if entries[i].Key != "" {
+			label += " " + highlightStyle.Render(entries[i].Key)
 		}
-		childPrefix := prefix + "│   "
-		if isLast {
-			childPrefix = prefix + "    "
+		fmt.Printf("  %s %s\n", label, truncate(t.Prompt, 50))
+		fmt.Printf("    %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(t.ID))
+		if t.DependsOn != "" {
+			fmt.Printf("    %s %s\n", subtitleStyle.Render("Depends on:"), idStyle.Render(t.DependsOn))
 		}
+	}
+	fmt.Println()
+	fmt.Println(successStyle.Render(fmt.Sprintf("Created %d task(s).", len(created))))
 
-		// Status badge
-		var statusBadge string
-		switch task.Status {
-		case "pending":
-			statusBadge = statusPendingStyle.Render("[pending]")
-		case "in-progress":
-			statusBadge = statusInProgressStyle.Render("[in-progress]")
-		case "completed":
-			statusBadge = statusCompletedStyle.Render("[completed]")
-		default:
-			statusBadge = subtitleStyle.Render(fmt.Sprintf("[%s]", task.Status))
+	if autoImplement {
+		fmt.Println()
+		ids := make([]string, len(created))
+		for i, t := range created {
+			ids[i] = t.ID
 		}
-
-		// Print task header
-		fmt.Printf("%s%s%s %s\n", prefix, branch, statusBadge, truncate(task.Prompt, 50))
-		fmt.Printf("%s%s %s\n", childPrefix, subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
-
-		// Print verification criteria
-		if len(task.VerificationCriteria) > 0 {
-			fmt.Printf("%s%s\n", childPrefix, subtitleStyle.Render("Criteria:"))
-			for _, c := range task.VerificationCriteria {
-				fmt.Printf("%s  • %s\n", childPrefix, c)
-			}
+		outcomes, err := implementTasks(ids, "")
+		if err != nil || outcomes == nil {
+			return err
 		}
+		return outcomes.checkFailures(allowFailures)
+	}
+	return nil
+}
 
-		// Print worktrees for this task
-		worktrees := worktreesByTask[task.ID]
-		children := childrenMap[task.ID]
-		hasMore := len(children) > 0
+// findDuplicateTask returns the existing task whose prompt is most similar to
+// prompt, if it is an exact (case-insensitive) match or more than 90% similar.
+func findDuplicateTask(tasks []Task, prompt string) (*Task, float64) {
+	normalized := normalizePrompt(prompt)
 
-		if len(worktrees) > 0 {
-			fmt.Printf("%s%s\n", childPrefix, subtitleStyle.Render("Worktrees:"))
-			for i, wt := range worktrees {
-				wtIsLast := i == len(worktrees)-1 && !hasMore
-				wtBranch := "├── "
-				if wtIsLast {
-					wtBranch = "└── "
-				}
+	var best *Task
+	var bestSimilarity float64
+	for i := range tasks {
+		if strings.EqualFold(strings.TrimSpace(tasks[i].Prompt), strings.TrimSpace(prompt)) {
+			return &tasks[i], 1
+		}
+		if s := promptSimilarity(normalized, normalizePrompt(tasks[i].Prompt)); s > 0.9 && s > bestSimilarity {
+			best, bestSimilarity = &tasks[i], s
+		}
+	}
+	return best, bestSimilarity
+}
 
-				// Worktree status
-				var wtStatus string
-				if wt.IsRunning {
-					wtStatus = statusInProgressStyle.Render("[running]")
-				} else if wt.HasChanges {
-					wtStatus = statusPendingStyle.Render("[modified]")
-				} else if wt.CommitsAhead != "0" {
-					wtStatus = statusCompletedStyle.Render("[" + wt.CommitsAhead + " commits]")
-				} else {
-					wtStatus = subtitleStyle.Render("[idle]")
-				}
+// normalizePrompt lowercases a prompt and collapses whitespace for comparison.
+func normalizePrompt(prompt string) string {
+	return strings.Join(strings.Fields(strings.ToLower(prompt)), " ")
+}
 
-				fmt.Printf("%s%s%s %s\n", childPrefix, wtBranch, wtStatus, wt.Name)
+// promptSimilarity returns 1 minus the Levenshtein distance between a and b
+// normalized by the longer length, so 1 means identical.
+func promptSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
 
-				// Show accept hint
-				if !wt.IsRunning && (wt.CommitsAhead != "0" || wt.HasChanges) {
-					wtChildPrefix := childPrefix + "│   "
-					if wtIsLast {
-						wtChildPrefix = childPrefix + "    "
-					}
-					fmt.Printf("%s%s autom8 accept %s\n", wtChildPrefix, highlightStyle.Render("→"), wt.Name)
-				}
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
 			}
-		} else if task.Status == "pending" {
-			fmt.Printf("%s%s\n", childPrefix, subtitleStyle.Render("(no worktrees - run 'autom8 implement')"))
-		}
-
-		// Print children (dependent tasks)
-		for i, childID := range children {
-			printTask(childID, childPrefix, i == len(children)-1)
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
 		}
+		prev, curr = curr, prev
 	}
+	return prev[len(b)]
+}
 
-	// Print all root tasks
-	for i, taskID := range rootTasks {
-		printTask(taskID, "", i == len(rootTasks)-1)
-		if i < len(rootTasks)-1 {
-			fmt.Println()
+// newTaskID returns a task ID that does not collide with any existing task.
+func newTaskID(tasks []Task) string {
+	for {
+		id := fmt.Sprintf("task-%d", time.Now().UnixNano())
+		taken := false
+		for _, t := range tasks {
+			if t.ID == id {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return id
 		}
 	}
+}
 
-	fmt.Println()
-	return nil
+// forgeIssue is the subset of issue fields used for importing
+type forgeIssue struct {
+	Number int
+	Title  string
+	Body   string
+	URL    string
 }
 
-func runAccept(cmd *cobra.Command, args []string) error {
-	if len(args) < 1 {
-		return fmt.Errorf("worktree name required\nRun 'autom8 status' to see available worktrees")
-	}
+// Forge abstracts the code hosting service used for issue import, status
+// comments and pull/merge requests.
+type Forge interface {
+	// Name returns the provider recorded in ExternalRef.Provider
+	Name() string
+	// Repo returns the repository path (owner/name or group/project)
+	Repo() string
+	ListIssues(labels []string, limit int) ([]forgeIssue, error)
+	// UpdateIssue comments on an issue and optionally closes it
+	UpdateIssue(number int, body string, closeIssue bool) error
+	// CreatePullRequest opens a pull/merge request and returns its URL
+	CreatePullRequest(branch, base, title, body string) (string, error)
+}
 
-	worktreeName := args[0]
+var (
+	// checklistPattern matches markdown task list items: "- [ ] item" or "* [x] item"
+	checklistPattern = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]\s+(.+)$`)
 
-	gitRoot, err := getGitRoot()
-	if err != nil {
-		return fmt.Errorf("error getting git root: %w", err)
-	}
+	// remoteURLPattern splits SSH (git@host:path), ssh:// and http(s):// remote URLs into host and path
+	remoteURLPattern = regexp.MustCompile(`^(?:[a-z+]+://)?(?:[^@/]+@)?([^/:]+)(?::\d+)?[:/](.+?)(?:\.git)?/?$`)
+)
 
-	autom8Path, err := getAutom8Dir()
+// taskExport is the file format written by 'autom8 export'
+type taskExport struct {
+	Version int    `json:"autom8_export"`
+	Tasks   []Task `json:"tasks"`
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	tasks, err := loadTasks()
 	if err != nil {
-		return fmt.Errorf("error getting autom8 dir: %w", err)
+		return fmt.Errorf("error loading tasks: %w", err)
 	}
 
-	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
-
-	// Check if worktree exists
-	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
-		return fmt.Errorf("worktree '%s' not found\nRun 'autom8 status' to see available worktrees", worktreeName)
+	export := taskExport{Version: 1, Tasks: []Task{}}
+	for _, t := range tasks {
+		if pendingOnly && t.Status != "pending" {
+			continue
+		}
+		export.Tasks = append(export.Tasks, t)
 	}
 
-	// Get the branch name from the worktree
-	branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
-	branchOutput, err := branchCmd.Output()
+	data, err := json.MarshalIndent(export, "", "  ")
 	if err != nil {
-		return fmt.Errorf("error getting branch name: %w", err)
+		return err
 	}
-	branchName := strings.TrimSpace(string(branchOutput))
+	fmt.Println(string(data))
+	return nil
+}
 
-	if branchName == "" {
-		return fmt.Errorf("could not determine branch name for worktree")
+func runImportFile(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
 	}
 
-	// Check for uncommitted changes in the worktree
-	statusCmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
-	statusOutput, err := statusCmd.Output()
+	data, err := os.ReadFile(args[0])
 	if err != nil {
-		return fmt.Errorf("error checking worktree status: %w", err)
+		return fmt.Errorf("error reading %s: %w", args[0], err)
 	}
 
-	if len(strings.TrimSpace(string(statusOutput))) > 0 {
-		fmt.Println(subtitleStyle.Render("Found uncommitted changes, auto-committing..."))
-
-		// Stage all changes
-		addCmd := exec.Command("git", "-C", worktreePath, "add", "-A")
-		if addOutput, err := addCmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("error staging changes: %w\n%s", err, string(addOutput))
+	// Accept an export file or a plain tasks.json
+	var export taskExport
+	if err := json.Unmarshal(data, &export); err != nil || export.Version == 0 {
+		export.Tasks = nil
+		if err := json.Unmarshal(data, &export.Tasks); err != nil {
+			return fmt.Errorf("%s is not an autom8 export or tasks.json file", args[0])
 		}
+	}
 
-		// Commit with auto-commit message
-		commitCmd := exec.Command("git", "-C", worktreePath, "commit", "-m", "autom8: auto-commit uncommitted changes")
-		if commitOutput, err := commitCmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("error committing changes: %w\n%s", err, string(commitOutput))
+	fmt.Println(titleStyle.Render("Importing Tasks"))
+	fmt.Println()
+
+	// Duplicates are checked and IDs assigned under the tasks lock, so a
+	// concurrent writer can't add the same prompt or take the same ID
+	var imported []Task
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		// Existing prompts, for duplicate detection
+		byPrompt := make(map[string]string)
+		for _, t := range tasks {
+			byPrompt[promptHash(t.Prompt)] = t.ID
 		}
-		fmt.Println(successStyle.Render("Auto-committed successfully."))
-	}
 
-	fmt.Printf("Merging branch '%s' into current branch...\n", highlightStyle.Render(branchName))
+		// Assign new IDs first so dependencies can be remapped in any order
+		idMap := make(map[string]string)
+		for _, t := range export.Tasks {
+			if existingID, ok := byPrompt[promptHash(t.Prompt)]; ok {
+				idMap[t.ID] = existingID
+				fmt.Printf("  %s %s (duplicate of %s)\n", subtitleStyle.Render("[skip]"), truncate(t.Prompt, 50), idStyle.Render(existingID))
+				continue
+			}
 
-	// Merge the branch into the current branch
-	mergeCmd := exec.Command("git", "-C", gitRoot, "merge", branchName, "-m", fmt.Sprintf("Merge %s (autom8 accept)", branchName))
-	mergeOutput, err := mergeCmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error merging branch: %w\n%s\nResolve conflicts manually, then run 'autom8 accept' again to clean up", err, string(mergeOutput))
-	}
-	fmt.Printf("%s", string(mergeOutput))
+			newTask := Task{
+				ID:                   newTaskID(append(tasks, imported...)),
+				Prompt:               t.Prompt,
+				VerificationCriteria: t.VerificationCriteria,
+				VerifyCommand:        t.VerifyCommand,
+				DependsOn:            t.DependsOn,
+				Epic:                 t.Epic,
+				CreatedAt:            time.Now(),
+				Status:               "pending",
+				ExternalRef:          t.ExternalRef,
+			}
+			idMap[t.ID] = newTask.ID
+			byPrompt[promptHash(t.Prompt)] = newTask.ID
+			imported = append(imported, newTask)
+		}
 
-	// Remove the worktree
-	fmt.Printf("Removing worktree '%s'...\n", worktreeName)
-	removeCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", worktreePath)
-	removeOutput, err := removeCmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error removing worktree: %w\n%s\nYou may need to manually remove it with: git worktree remove %s", err, string(removeOutput), worktreePath)
-	}
+		for i, t := range imported {
+			if t.DependsOn == "" {
+				continue
+			}
+			if newID, ok := idMap[t.DependsOn]; ok {
+				imported[i].DependsOn = newID
+			} else {
+				fmt.Printf("  %s %s depended on %s, which is not in the file; dependency dropped\n", errorStyle.Render("Warning:"), truncate(t.Prompt, 40), t.DependsOn)
+				imported[i].DependsOn = ""
+			}
+		}
 
-	// Delete the branch (it's been merged)
-	fmt.Printf("Deleting branch '%s'...\n", branchName)
-	deleteBranchCmd := exec.Command("git", "-C", gitRoot, "branch", "-d", branchName)
-	deleteBranchOutput, err := deleteBranchCmd.CombinedOutput()
+		if len(imported) == 0 {
+			return nil, nil
+		}
+		for _, t := range imported {
+			fmt.Printf("  %s %s\n", successStyle.Render("[imported]"), truncate(t.Prompt, 50))
+			fmt.Printf("    %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(t.ID))
+		}
+		return append(tasks, imported...), nil
+	})
 	if err != nil {
-		fmt.Printf("%s could not delete branch: %v\n%s\n", errorStyle.Render("Warning:"), err, string(deleteBranchOutput))
-		fmt.Println("The branch may need to be deleted manually with: git branch -D", branchName)
-	}
-
-	// Mark the task as completed
-	// Worktree name format: task-{timestamp}-{instance} (e.g., task-1769877109920033000-1)
-	// Extract task ID by removing the last -{instance} suffix
-	taskID := worktreeName
-	if lastDash := strings.LastIndex(worktreeName, "-"); lastDash > 0 {
-		taskID = worktreeName[:lastDash]
+		return fmt.Errorf("error saving tasks: %w", err)
 	}
 
-	tasks, err := loadTasks()
-	if err != nil {
-		fmt.Printf("%s could not load tasks to update status: %v\n", errorStyle.Render("Warning:"), err)
-	} else {
-		for i, t := range tasks {
-			if t.ID == taskID {
-				tasks[i].Status = "completed"
-				if err := saveTasks(tasks); err != nil {
-					fmt.Printf("%s could not save task status: %v\n", errorStyle.Render("Warning:"), err)
-				} else {
-					fmt.Printf("Marked task '%s' as completed.\n", taskID)
-				}
-				break
-			}
-		}
+	if len(imported) == 0 {
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render("Nothing new to import."))
+		return nil
 	}
 
 	fmt.Println()
-	fmt.Println(successStyle.Render(fmt.Sprintf("Successfully accepted worktree '%s'", worktreeName)))
+	fmt.Println(successStyle.Render(fmt.Sprintf("Imported %d task(s).", len(imported))))
 	return nil
 }
 
-func runDelete(cmd *cobra.Command, args []string) error {
-	if len(args) < 1 {
-		return fmt.Errorf("task ID required\nRun 'autom8 list' to see task IDs")
-	}
+// promptHash identifies a prompt regardless of case and whitespace.
+func promptHash(prompt string) string {
+	sum := sha256.Sum256([]byte(normalizePrompt(prompt)))
+	return hex.EncodeToString(sum[:])
+}
 
-	taskID := args[0]
+func runImportGithub(cmd *cobra.Command, args []string) error {
+	return runImportIssues("github")
+}
+
+func runImportGitlab(cmd *cobra.Command, args []string) error {
+	return runImportIssues("gitlab")
+}
 
+func runImportIssues(kind string) error {
 	gitRoot, err := getGitRoot()
 	if err != nil {
 		return err
 	}
 
-	tasks, err := loadTasks()
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
+		return fmt.Errorf("error loading config: %w", err)
 	}
 
-	// Find the task
-	taskIndex := -1
-	for i, t := range tasks {
-		if t.ID == taskID {
-			taskIndex = i
-			break
+	repo := importRepo
+	if repo == "" && (cfg.Forge.Type == "" || cfg.Forge.Type == kind) {
+		repo = cfg.Forge.Repo
+	}
+	if repo == "" {
+		_, path, err := originRemote(gitRoot)
+		if err != nil {
+			return fmt.Errorf("%w; pass --repo", err)
 		}
+		repo = path
 	}
 
-	if taskIndex == -1 {
-		return fmt.Errorf("task '%s' not found\nRun 'autom8 list' to see task IDs", taskID)
+	forge, err := newForge(kind, repo, cfg.Forge, gitRoot)
+	if err != nil {
+		return err
 	}
 
-	// Check if any other tasks depend on this one
-	var dependents []string
-	for _, t := range tasks {
-		if t.DependsOn == taskID {
-			dependents = append(dependents, t.ID)
-		}
+	issues, err := forge.ListIssues(importLabels, importLimit)
+	if err != nil {
+		return err
 	}
 
-	if len(dependents) > 0 {
-		msg := fmt.Sprintf("cannot delete task '%s' because these tasks depend on it:\n", taskID)
-		for _, dep := range dependents {
-			msg += fmt.Sprintf("  - %s\n", dep)
-		}
-		msg += "Delete the dependent tasks first, or use a different approach."
-		return fmt.Errorf(msg)
+	if len(issues) == 0 {
+		fmt.Println(subtitleStyle.Render("No matching open issues found."))
+		return nil
 	}
 
-	// Clean up associated worktrees
-	autom8Path, _ := getAutom8Dir()
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	var worktreesRemoved int
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Importing %s Issues", forgeDisplayName(kind))))
+	fmt.Println()
 
-	if entries, err := os.ReadDir(worktreesDir); err == nil {
-		for _, entry := range entries {
-			if !entry.IsDir() {
-				continue
-			}
-			worktreeName := entry.Name()
-			// Check if worktree belongs to this task (task-{id}-{instance})
-			if strings.HasPrefix(worktreeName, taskID+"-") {
-				worktreePath := filepath.Join(worktreesDir, worktreeName)
-				// Get branch name before removing
-				branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
-				branchOutput, _ := branchCmd.Output()
-				branchName := strings.TrimSpace(string(branchOutput))
-
-				// Remove worktree
-				removeCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", "--force", worktreePath)
-				if removeCmd.Run() == nil {
-					worktreesRemoved++
-					// Delete the branch
-					if branchName != "" {
-						deleteBranchCmd := exec.Command("git", "-C", gitRoot, "branch", "-D", branchName)
-						deleteBranchCmd.Run()
-					}
-				}
+	// Issues are fetched first, but checked for duplicates and given IDs under
+	// the tasks lock, so a concurrent import can't create them twice
+	var created []Task
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		// Index already-imported issues to skip duplicates
+		imported := make(map[string]string)
+		for _, t := range tasks {
+			if t.ExternalRef != nil {
+				imported[externalRefKey(*t.ExternalRef)] = t.ID
 			}
 		}
-	}
 
-	// Remove the task
-	tasks = append(tasks[:taskIndex], tasks[taskIndex+1:]...)
+		for _, issue := range issues {
+			ref := ExternalRef{
+				Provider: forge.Name(),
+				Repo:     forge.Repo(),
+				Number:   issue.Number,
+				URL:      issue.URL,
+			}
+
+			if existingID, ok := imported[externalRefKey(ref)]; ok {
+				fmt.Printf("  %s #%d %s (already imported as %s)\n", subtitleStyle.Render("[skip]"), issue.Number, truncate(issue.Title, 40), idStyle.Render(existingID))
+				continue
+			}
+
+			prompt := strings.TrimSpace(issue.Title)
+			if body := strings.TrimSpace(issue.Body); body != "" {
+				prompt += "\n\n" + body
+			}
+
+			task := Task{
+				ID:                   newTaskID(tasks),
+				Prompt:               prompt,
+				VerificationCriteria: parseChecklist(issue.Body),
+				CreatedAt:            time.Now(),
+				Status:               "pending",
+				ExternalRef:          &ref,
+			}
+			tasks = append(tasks, task)
+			created = append(created, task)
+			imported[externalRefKey(ref)] = task.ID
 
-	if err := saveTasks(tasks); err != nil {
+			fmt.Printf("  %s #%d %s\n", successStyle.Render("[imported]"), issue.Number, truncate(issue.Title, 50))
+			fmt.Printf("    %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
+		}
+		if len(created) == 0 {
+			return nil, nil
+		}
+		return tasks, nil
+	})
+	if err != nil {
 		return fmt.Errorf("error saving tasks: %w", err)
 	}
 
-	if worktreesRemoved > 0 {
-		fmt.Println(successStyle.Render(fmt.Sprintf("Task '%s' deleted, removed %d worktree(s).", taskID, worktreesRemoved)))
-	} else {
-		fmt.Println(successStyle.Render(fmt.Sprintf("Task '%s' deleted.", taskID)))
+	if len(created) == 0 {
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render("Nothing new to import."))
+		return nil
 	}
+
+	fmt.Println()
+	fmt.Println(successStyle.Render(fmt.Sprintf("Imported %d issue(s).", len(created))))
 	return nil
 }
 
-func runPrune(cmd *cobra.Command, args []string) error {
-	gitRoot, err := getGitRoot()
-	if err != nil {
-		return err
-	}
+func externalRefKey(ref ExternalRef) string {
+	return fmt.Sprintf("%s:%s#%d", ref.Provider, strings.ToLower(ref.Repo), ref.Number)
+}
 
-	tasks, err := loadTasks()
-	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
+func forgeDisplayName(kind string) string {
+	switch kind {
+	case "github":
+		return "GitHub"
+	case "gitlab":
+		return "GitLab"
 	}
+	return kind
+}
 
-	autom8Path, _ := getAutom8Dir()
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-
-	var remaining []Task
-	var pruned int
-	var worktreesRemoved int
-
-	for _, t := range tasks {
-		if t.Status == "completed" {
-			pruned++
-			// Find and remove worktrees for this task
-			if entries, err := os.ReadDir(worktreesDir); err == nil {
-				for _, entry := range entries {
-					if !entry.IsDir() {
-						continue
-					}
-					worktreeName := entry.Name()
-					// Check if worktree belongs to this task (task-{id}-{instance})
-					if strings.HasPrefix(worktreeName, t.ID+"-") {
-						worktreePath := filepath.Join(worktreesDir, worktreeName)
-						// Get branch name before removing
-						branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
-						branchOutput, _ := branchCmd.Output()
-						branchName := strings.TrimSpace(string(branchOutput))
-
-						// Remove worktree
-						removeCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", "--force", worktreePath)
-						if removeCmd.Run() == nil {
-							worktreesRemoved++
-							// Delete the branch
-							if branchName != "" {
-								deleteBranchCmd := exec.Command("git", "-C", gitRoot, "branch", "-D", branchName)
-								deleteBranchCmd.Run()
-							}
-						}
-					}
-				}
-			}
-		} else {
-			remaining = append(remaining, t)
+// parseChecklist returns the text of every markdown checklist item in body.
+func parseChecklist(body string) []string {
+	var items []string
+	for _, line := range strings.Split(body, "\n") {
+		if m := checklistPattern.FindStringSubmatch(strings.TrimRight(line, "\r")); m != nil {
+			items = append(items, strings.TrimSpace(m[1]))
 		}
 	}
+	return items
+}
 
-	if pruned == 0 {
-		fmt.Println(subtitleStyle.Render("No completed tasks to prune."))
-		return nil
+// originRemote returns the host and repository path of the origin remote.
+func originRemote(gitRoot string) (string, string, error) {
+	remoteCmd := exec.Command("git", "-C", gitRoot, "remote", "get-url", "origin")
+	output, err := remoteCmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("could not read the origin remote")
 	}
 
-	if err := saveTasks(remaining); err != nil {
-		return fmt.Errorf("error saving tasks: %w", err)
+	remote := strings.TrimSpace(string(output))
+	m := remoteURLPattern.FindStringSubmatch(remote)
+	if m == nil {
+		return "", "", fmt.Errorf("could not parse origin remote '%s'", remote)
 	}
-
-	fmt.Println(successStyle.Render(fmt.Sprintf("Pruned %d completed task(s), removed %d worktree(s).", pruned, worktreesRemoved)))
-	return nil
+	return m[1], m[2], nil
 }
 
-func runInspect(cmd *cobra.Command, args []string) error {
-	worktreeName := args[0]
+// detectForge picks the forge and repository from forge.type and
+// forge.repo in config, falling back to the origin remote for whichever is
+// unset.
+func detectForge(gitRoot string, cfg Config) (Forge, error) {
+	kind, repo := cfg.Forge.Type, cfg.Forge.Repo
+	if kind != "" && repo != "" {
+		return newForge(kind, repo, cfg.Forge, gitRoot)
+	}
 
-	autom8Path, err := getAutom8Dir()
+	host, path, err := originRemote(gitRoot)
 	if err != nil {
-		return fmt.Errorf("error getting autom8 dir: %w", err)
+		if kind == "" {
+			return nil, fmt.Errorf("%w; set forge.type in %s/%s", err, autom8Dir, configFile)
+		}
+		return nil, fmt.Errorf("%w; set forge.repo in %s/%s", err, autom8Dir, configFile)
+	}
+	if kind == "" {
+		switch {
+		case strings.Contains(host, "github"):
+			kind = "github"
+		case strings.Contains(host, "gitlab"):
+			kind = "gitlab"
+		default:
+			return nil, fmt.Errorf("could not detect the forge for '%s'; set forge.type in %s/%s", host, autom8Dir, configFile)
+		}
+	}
+	if repo == "" {
+		repo = path
 	}
 
-	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+	return newForge(kind, repo, cfg.Forge, gitRoot)
+}
 
-	// Check if worktree exists
-	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
-		return fmt.Errorf("worktree '%s' not found\nRun 'autom8 status' to see available worktrees", worktreeName)
+// newForge builds a forge client for a repository. Base URL and token from
+// config only apply when forge.type is unset or matches kind.
+func newForge(kind, repo string, fc ForgeConfig, gitRoot string) (Forge, error) {
+	if fc.Type != "" && fc.Type != kind {
+		fc = ForgeConfig{}
 	}
+	baseURL := strings.TrimRight(fc.BaseURL, "/")
 
-	// Get worktree info for display
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	pids, _ := loadPids()
-	info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+	switch kind {
+	case "github":
+		f := &githubForge{repo: repo, gitRoot: gitRoot, token: fc.Token, apiURL: "https://api.github.com", ghRepo: repo}
+		if f.token == "" {
+			f.token = os.Getenv("GITHUB_TOKEN")
+		}
+		if baseURL != "" && baseURL != "https://github.com" {
+			// GitHub Enterprise Server
+			f.apiURL = baseURL + "/api/v3"
+			if u, err := url.Parse(baseURL); err == nil {
+				f.ghRepo = u.Host + "/" + repo
+			}
+		}
+		if _, err := exec.LookPath("gh"); err == nil {
+			f.useGh = true
+		} else if f.token == "" {
+			return nil, fmt.Errorf("neither the gh CLI nor a GitHub token is available\nInstall gh (https://cli.github.com), export GITHUB_TOKEN or set forge.token")
+		}
+		return f, nil
+	case "gitlab":
+		f := &gitlabForge{repo: repo, token: fc.Token, apiURL: "https://gitlab.com/api/v4"}
+		if f.token == "" {
+			f.token = os.Getenv("GITLAB_TOKEN")
+		}
+		if baseURL != "" {
+			f.apiURL = baseURL + "/api/v4"
+		}
+		if f.token == "" {
+			return nil, fmt.Errorf("no GitLab token available\nExport GITLAB_TOKEN or set forge.token")
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported forge '%s' (expected github or gitlab)", kind)
+}
 
-	fmt.Println(titleStyle.Render("Inspecting Worktree"))
-	fmt.Println()
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Worktree:"), highlightStyle.Render(worktreeName))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(info.Branch))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Path:"), worktreePath)
-	fmt.Println()
-	fmt.Println(subtitleStyle.Render("Starting a new shell in the worktree directory..."))
-	fmt.Println(subtitleStyle.Render("Type 'exit' or press Ctrl+D to return."))
-	fmt.Println()
+// forgeRequest sends an optional JSON payload and decodes the JSON response into out.
+func forgeRequest(method, apiURL string, headers map[string]string, payload, out any) error {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
 
-	// Determine which shell to use
-	shell := os.Getenv("SHELL")
-	if shell == "" {
-		shell = "/bin/sh"
+	req, err := http.NewRequest(method, apiURL, reqBody)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// Start an interactive shell in the worktree directory
-	shellCmd := exec.Command(shell)
-	shellCmd.Dir = worktreePath
-	shellCmd.Stdin = os.Stdin
-	shellCmd.Stdout = os.Stdout
-	shellCmd.Stderr = os.Stderr
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-	// Set a custom prompt to remind the user they're in an autom8 worktree
-	env := os.Environ()
-	env = append(env, fmt.Sprintf("AUTOM8_WORKTREE=%s", worktreeName))
-	shellCmd.Env = env
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned %s\n%s", method, apiURL, resp.Status, strings.TrimSpace(string(body)))
+	}
 
-	if err := shellCmd.Run(); err != nil {
-		// Exit code from shell is not an error for us
-		if _, ok := err.(*exec.ExitError); !ok {
-			return fmt.Errorf("error running shell: %w", err)
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error parsing response: %w", err)
 		}
 	}
-
-	fmt.Println()
-	fmt.Println(successStyle.Render("Exited worktree inspection."))
 	return nil
 }
 
-func runShow(cmd *cobra.Command, args []string) error {
-	worktreeName := args[0]
+// githubForge talks to GitHub through the gh CLI when installed, otherwise the REST API
+type githubForge struct {
+	repo    string
+	ghRepo  string // [HOST/]OWNER/REPO as understood by gh
+	gitRoot string
+	apiURL  string
+	token   string
+	useGh   bool
+}
 
-	autom8Path, err := getAutom8Dir()
+func (f *githubForge) Name() string { return "github" }
+func (f *githubForge) Repo() string { return f.repo }
+
+// gh runs the gh CLI in the repository and returns its stdout.
+func (f *githubForge) gh(args ...string) ([]byte, error) {
+	ghCmd := exec.Command("gh", args...)
+	ghCmd.Dir = f.gitRoot
+	output, err := ghCmd.Output()
 	if err != nil {
-		return fmt.Errorf("error getting autom8 dir: %w", err)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh %s failed: %w\n%s", args[0], err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("gh %s failed: %w", args[0], err)
 	}
+	return output, nil
+}
 
-	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
-
-	// Check if worktree exists
-	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
-		return fmt.Errorf("worktree '%s' not found\nRun 'autom8 status' to see available worktrees", worktreeName)
+func (f *githubForge) api(method, path string, payload, out any) error {
+	headers := map[string]string{
+		"Accept":        "application/vnd.github+json",
+		"Authorization": "Bearer " + f.token,
 	}
+	return forgeRequest(method, f.apiURL+path, headers, payload, out)
+}
 
-	// Get worktree info for display
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	pids, _ := loadPids()
-	info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+func (f *githubForge) ListIssues(labels []string, limit int) ([]forgeIssue, error) {
+	if f.useGh {
+		ghArgs := []string{"issue", "list", "--repo", f.ghRepo, "--state", "open", "--json", "number,title,body,url", "--limit", fmt.Sprintf("%d", limit)}
+		for _, l := range labels {
+			ghArgs = append(ghArgs, "--label", l)
+		}
+		output, err := f.gh(ghArgs...)
+		if err != nil {
+			return nil, err
+		}
 
-	// Print header info directly to stdout
-	fmt.Println(titleStyle.Render(fmt.Sprintf("Diff: main...%s", info.Branch)))
-	fmt.Println()
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Worktree:"), highlightStyle.Render(worktreeName))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(info.Branch))
-	fmt.Printf("  %s %s commit(s) ahead of main\n", subtitleStyle.Render("Commits:"), info.CommitsAhead)
-	fmt.Println()
+		var ghIssues []struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			Body   string `json:"body"`
+			URL    string `json:"url"`
+		}
+		if err := json.Unmarshal(output, &ghIssues); err != nil {
+			return nil, fmt.Errorf("error parsing gh output: %w", err)
+		}
 
-	// Get the diff between main and the worktree branch
-	diffCmd := exec.Command("git", "-C", worktreePath, "diff", "main...HEAD", "--stat")
-	statOutput, _ := diffCmd.Output()
+		issues := make([]forgeIssue, 0, len(ghIssues))
+		for _, i := range ghIssues {
+			issues = append(issues, forgeIssue{Number: i.Number, Title: i.Title, Body: i.Body, URL: i.URL})
+		}
+		return issues, nil
+	}
 
-	if len(statOutput) > 0 {
-		fmt.Println(subtitleStyle.Render("Files changed:"))
-		fmt.Println(string(statOutput))
+	query := url.Values{}
+	query.Set("state", "open")
+	query.Set("per_page", fmt.Sprintf("%d", min(limit, 100)))
+	if len(labels) > 0 {
+		query.Set("labels", strings.Join(labels, ","))
 	}
 
-	// Get the full diff
-	fullDiffCmd := exec.Command("git", "-C", worktreePath, "diff", "main...HEAD")
-	fullDiffOutput, err := fullDiffCmd.Output()
-	if err != nil {
-		return fmt.Errorf("error getting diff: %w", err)
+	var apiIssues []struct {
+		Number      int             `json:"number"`
+		Title       string          `json:"title"`
+		Body        string          `json:"body"`
+		HTMLURL     string          `json:"html_url"`
+		PullRequest json.RawMessage `json:"pull_request"`
+	}
+	if err := f.api("GET", fmt.Sprintf("/repos/%s/issues?%s", f.repo, query.Encode()), nil, &apiIssues); err != nil {
+		return nil, fmt.Errorf("error fetching issues: %w", err)
 	}
 
-	if len(fullDiffOutput) == 0 {
-		fmt.Println(subtitleStyle.Render("No changes from main."))
-		return nil
+	var issues []forgeIssue
+	for _, i := range apiIssues {
+		// The issues endpoint also returns pull requests
+		if i.PullRequest != nil {
+			continue
+		}
+		issues = append(issues, forgeIssue{Number: i.Number, Title: i.Title, Body: i.Body, URL: i.HTMLURL})
 	}
+	return issues, nil
+}
 
-	fmt.Println(subtitleStyle.Render("Diff:"))
-	fmt.Println()
+func (f *githubForge) UpdateIssue(number int, body string, closeIssue bool) error {
+	num := fmt.Sprintf("%d", number)
 
-	// Pipe the full diff through less for scrollable viewing
-	// Fall back to direct print if less is unavailable
-	if err := pipeToLess(fullDiffOutput); err != nil {
-		// Fallback: print directly to stdout
-		fmt.Println(string(fullDiffOutput))
+	if f.useGh {
+		ghArgs := []string{"issue", "comment", num, "--repo", f.ghRepo, "--body", body}
+		if closeIssue {
+			// gh issue close posts the comment and closes in one step
+			ghArgs = []string{"issue", "close", num, "--repo", f.ghRepo, "--comment", body}
+		}
+		_, err := f.gh(ghArgs...)
+		return err
 	}
 
+	issuePath := fmt.Sprintf("/repos/%s/issues/%d", f.repo, number)
+	if err := f.api("POST", issuePath+"/comments", map[string]string{"body": body}, nil); err != nil {
+		return err
+	}
+	if closeIssue {
+		return f.api("PATCH", issuePath, map[string]string{"state": "closed"}, nil)
+	}
 	return nil
 }
 
-// pipeToLess pipes the given content through the less pager for scrollable viewing.
-// Returns an error if less is unavailable or fails to run.
-func pipeToLess(content []byte) error {
-	// Check if less is available
-	lessPath, err := exec.LookPath("less")
-	if err != nil {
-		return fmt.Errorf("less not found: %w", err)
+func (f *githubForge) CreatePullRequest(branch, base, title, body string) (string, error) {
+	if f.useGh {
+		output, err := f.gh("pr", "create", "--repo", f.ghRepo, "--head", branch, "--base", base, "--title", title, "--body", body)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(output)), nil
 	}
 
-	// Create the less command with options for color support
-	lessCmd := exec.Command(lessPath, "-R")
-	lessCmd.Stdout = os.Stdout
-	lessCmd.Stderr = os.Stderr
-
-	// Get stdin pipe to write content
-	stdin, err := lessCmd.StdinPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdin pipe: %w", err)
+	var pr struct {
+		HTMLURL string `json:"html_url"`
 	}
-
-	// Start less
-	if err := lessCmd.Start(); err != nil {
-		return fmt.Errorf("failed to start less: %w", err)
+	payload := map[string]string{"title": title, "head": branch, "base": base, "body": body}
+	if err := f.api("POST", fmt.Sprintf("/repos/%s/pulls", f.repo), payload, &pr); err != nil {
+		return "", err
 	}
+	return pr.HTMLURL, nil
+}
 
-	// Write content to less stdin
-	stdin.Write(content)
-	stdin.Close()
+// gitlabForge talks to GitLab (gitlab.com or self-hosted) through the REST API
+type gitlabForge struct {
+	repo   string
+	apiURL string
+	token  string
+}
 
-	// Wait for less to finish (user quits with 'q')
-	if err := lessCmd.Wait(); err != nil {
-		// Ignore exit errors from less (e.g., user pressing 'q')
-		if _, ok := err.(*exec.ExitError); !ok {
-			return fmt.Errorf("less failed: %w", err)
-		}
-	}
+func (f *gitlabForge) Name() string { return "gitlab" }
+func (f *gitlabForge) Repo() string { return f.repo }
 
-	return nil
+func (f *gitlabForge) api(method, path string, payload, out any) error {
+	headers := map[string]string{"PRIVATE-TOKEN": f.token}
+	project := url.PathEscape(f.repo)
+	return forgeRequest(method, fmt.Sprintf("%s/projects/%s%s", f.apiURL, project, path), headers, payload, out)
 }
 
-func runChat(cmd *cobra.Command, args []string) error {
-	worktreeName := args[0]
+func (f *gitlabForge) ListIssues(labels []string, limit int) ([]forgeIssue, error) {
+	query := url.Values{}
+	query.Set("state", "opened")
+	query.Set("per_page", fmt.Sprintf("%d", min(limit, 100)))
+	if len(labels) > 0 {
+		query.Set("labels", strings.Join(labels, ","))
+	}
 
-	autom8Path, err := getAutom8Dir()
-	if err != nil {
-		return fmt.Errorf("error getting autom8 dir: %w", err)
+	var apiIssues []struct {
+		IID         int    `json:"iid"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		WebURL      string `json:"web_url"`
+	}
+	if err := f.api("GET", "/issues?"+query.Encode(), nil, &apiIssues); err != nil {
+		return nil, fmt.Errorf("error fetching issues: %w", err)
 	}
 
-	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+	issues := make([]forgeIssue, 0, len(apiIssues))
+	for _, i := range apiIssues {
+		issues = append(issues, forgeIssue{Number: i.IID, Title: i.Title, Body: i.Description, URL: i.WebURL})
+	}
+	return issues, nil
+}
 
-	// Check if worktree exists
-	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
-		return fmt.Errorf("worktree '%s' not found\nRun 'autom8 status' to see available worktrees", worktreeName)
+func (f *gitlabForge) UpdateIssue(number int, body string, closeIssue bool) error {
+	issuePath := fmt.Sprintf("/issues/%d", number)
+	if err := f.api("POST", issuePath+"/notes", map[string]string{"body": body}, nil); err != nil {
+		return err
+	}
+	if closeIssue {
+		return f.api("PUT", issuePath, map[string]string{"state_event": "close"}, nil)
 	}
+	return nil
+}
 
-	// Extract task ID from worktree name: task-{timestamp}-{instance} -> task-{timestamp}
-	taskID := worktreeName
-	if lastDash := strings.LastIndex(worktreeName, "-"); lastDash > 0 {
-		taskID = worktreeName[:lastDash]
+func (f *gitlabForge) CreatePullRequest(branch, base, title, body string) (string, error) {
+	var mr struct {
+		WebURL string `json:"web_url"`
+	}
+	payload := map[string]string{
+		"source_branch": branch,
+		"target_branch": base,
+		"title":         title,
+		"description":   body,
+	}
+	if err := f.api("POST", "/merge_requests", payload, &mr); err != nil {
+		return "", err
 	}
+	return mr.WebURL, nil
+}
 
-	// Load task details
-	tasks, err := loadTasks()
+// issueSync batches status updates for tasks linked to issues, so that a
+// single command run posts at most one comment per issue. Failures only warn.
+type issueSync struct {
+	enabled bool
+	gitRoot string
+	forge   ForgeConfig
+	order   []string
+	updates map[string]*issueUpdate
+}
+
+type issueUpdate struct {
+	ref      ExternalRef
+	messages []string
+	close    bool
+}
+
+// newIssueSync returns a syncer that is a no-op unless issues.sync_status is enabled.
+func newIssueSync(gitRoot string) *issueSync {
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
+		fmt.Printf("%s could not load config, issue sync disabled: %v\n", errorStyle.Render("Warning:"), err)
 	}
+	return &issueSync{
+		enabled: err == nil && cfg.Issues.SyncStatus,
+		gitRoot: gitRoot,
+		forge:   cfg.Forge,
+		updates: make(map[string]*issueUpdate),
+	}
+}
 
-	var task *Task
-	for i := range tasks {
-		if tasks[i].ID == taskID {
-			task = &tasks[i]
-			break
-		}
+// add queues a comment for the task's linked issue, if any.
+func (s *issueSync) add(task Task, message string) {
+	if !s.enabled || task.ExternalRef == nil {
+		return
 	}
+	key := externalRefKey(*task.ExternalRef)
+	update, ok := s.updates[key]
+	if !ok {
+		update = &issueUpdate{ref: *task.ExternalRef}
+		s.updates[key] = update
+		s.order = append(s.order, key)
+	}
+	update.messages = append(update.messages, message)
+}
 
-	if task == nil {
-		return fmt.Errorf("task '%s' not found for worktree '%s'", taskID, worktreeName)
+// close queues a final comment and closes the task's linked issue.
+func (s *issueSync) close(task Task, message string) {
+	if !s.enabled || task.ExternalRef == nil {
+		return
 	}
+	s.add(task, message)
+	s.updates[externalRefKey(*task.ExternalRef)].close = true
+}
 
-	// Get worktree info for display
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	pids, _ := loadPids()
-	info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+// flush posts the queued updates, one comment per issue.
+func (s *issueSync) flush() {
+	for _, key := range s.order {
+		update := s.updates[key]
+		body := strings.Join(update.messages, "\n\n")
 
-	// Gather git log since branching from main
-	logCmd := exec.Command("git", "-C", worktreePath, "log", "--oneline", "main..HEAD")
-	logOutput, _ := logCmd.Output()
+		forge, err := newForge(update.ref.Provider, update.ref.Repo, s.forge, s.gitRoot)
+		if err == nil {
+			err = forge.UpdateIssue(update.ref.Number, body, update.close)
+		}
+		if err != nil {
+			fmt.Printf("%s could not update issue %s: %v\n", errorStyle.Render("Warning:"), update.ref.URL, err)
+		}
+	}
+	s.order = nil
+	s.updates = make(map[string]*issueUpdate)
+}
 
-	// Gather diff from main
-	diffCmd := exec.Command("git", "-C", worktreePath, "diff", "main...HEAD")
-	diffOutput, _ := diffCmd.Output()
+// notification is a lifecycle event sent to the configured webhook
+type notification struct {
+	Event    string // worktree_completed, worktree_failed, worktree_stopped, converge_winner, accept_merged
+	Task     Task
+	Worktree string
+	Duration time.Duration
+}
 
-	// Build system prompt with context
-	systemPrompt := buildChatSystemPrompt(task, worktreeName, info.Branch, string(logOutput), string(diffOutput))
+// notificationPayload is the default JSON body, and the data passed to a custom template
+type notificationPayload struct {
+	Event           string    `json:"event"`
+	TaskID          string    `json:"task_id"`
+	Prompt          string    `json:"prompt"`
+	Worktree        string    `json:"worktree,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Text            string    `json:"text"` // One-line human readable summary
+}
 
-	// Display worktree info before starting
-	fmt.Println(titleStyle.Render("Interactive Chat Session"))
-	fmt.Println()
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Worktree:"), highlightStyle.Render(worktreeName))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(info.Branch))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Task ID:"), idStyle.Render(taskID))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Task:"), truncate(task.Prompt, 60))
-	if info.CommitsAhead != "0" {
-		fmt.Printf("  %s %s commit(s) ahead of main\n", subtitleStyle.Render("Progress:"), info.CommitsAhead)
-	}
-	fmt.Println()
-	fmt.Println(subtitleStyle.Render("Starting interactive Claude session with task context..."))
-	fmt.Println(subtitleStyle.Render("Type your questions or instructions. Use Ctrl+C to exit."))
-	fmt.Println()
+func newNotificationPayload(ev notification) notificationPayload {
+	payload := notificationPayload{
+		Event:           ev.Event,
+		TaskID:          ev.Task.ID,
+		Prompt:          truncate(ev.Task.Prompt, 200),
+		Worktree:        ev.Worktree,
+		Timestamp:       time.Now(),
+		DurationSeconds: ev.Duration.Round(time.Second).Seconds(),
+	}
+	payload.Text = fmt.Sprintf("autom8: %s %s", strings.ReplaceAll(ev.Event, "_", " "), ev.Task.ID)
+	if ev.Worktree != "" {
+		payload.Text += fmt.Sprintf(" (%s)", ev.Worktree)
+	}
+	payload.Text += " - " + truncate(ev.Task.Prompt, 80)
+	return payload
+}
 
-	// Launch interactive Claude session with system prompt
-	claudeCmd := exec.Command("claude", "--dangerously-skip-permissions", "--system-prompt", systemPrompt)
-	claudeCmd.Dir = worktreePath
-	claudeCmd.Stdin = os.Stdin
-	claudeCmd.Stdout = os.Stdout
-	claudeCmd.Stderr = os.Stderr
+// notifier posts lifecycle events to notifications.webhook_url and shows
+// desktop notifications. A nil notifier is valid and sends nothing.
+// Failures only warn.
+type notifier struct {
+	url                string
+	template           *template.Template
+	desktop            bool
+	desktopPerWorktree bool
+	progress           *progressDisplay // Warnings are printed above it when set
+}
 
-	if err := claudeCmd.Run(); err != nil {
-		// Exit code from claude is not necessarily an error for us
-		if _, ok := err.(*exec.ExitError); !ok {
-			return fmt.Errorf("error running claude: %w", err)
-		}
+// newNotifier returns nil unless a webhook or desktop notifications are
+// enabled and --no-notify is unset.
+func newNotifier(progress *progressDisplay) *notifier {
+	if noNotifyFlag {
+		return nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Printf("%s could not load config, notifications disabled: %v\n", errorStyle.Render("Warning:"), err)
+		return nil
+	}
+	desktop := notifyFlag || cfg.Notifications.Desktop
+	if cfg.Notifications.WebhookURL == "" && !desktop && !cfg.Notifications.DesktopPerWorktree {
+		return nil
 	}
 
-	fmt.Println()
-	fmt.Println(successStyle.Render("Chat session ended."))
-	return nil
+	n := &notifier{
+		url:                cfg.Notifications.WebhookURL,
+		desktop:            desktop,
+		desktopPerWorktree: cfg.Notifications.DesktopPerWorktree,
+		progress:           progress,
+	}
+	if cfg.Notifications.Template != "" {
+		funcs := template.FuncMap{
+			// json renders a value as a JSON literal, e.g. {"text": {{json .Text}}}
+			"json": func(v any) (string, error) {
+				data, err := json.Marshal(v)
+				return string(data), err
+			},
+		}
+		tmpl, err := template.New("webhook").Funcs(funcs).Parse(cfg.Notifications.Template)
+		if err != nil {
+			fmt.Printf("%s invalid notifications.template, using the default payload: %v\n", errorStyle.Render("Warning:"), err)
+		} else {
+			n.template = tmpl
+		}
+	}
+	return n
 }
 
-func buildChatSystemPrompt(task *Task, worktreeName, branchName, gitLog, gitDiff string) string {
-	var sb strings.Builder
-
-	sb.WriteString("# Context for This Worktree\n\n")
-	sb.WriteString("You are assisting with an implementation task in a git worktree. ")
-	sb.WriteString("The user wants to either ask questions about the implementation or give you instructions to continue/fix it.\n\n")
-
-	sb.WriteString("## Original Task\n\n")
-	sb.WriteString(task.Prompt)
-	sb.WriteString("\n\n")
+// send posts an event to the webhook.
+func (n *notifier) send(ev notification) {
+	if n == nil {
+		return
+	}
 
-	if len(task.VerificationCriteria) > 0 {
-		sb.WriteString("## Verification Criteria\n\n")
-		sb.WriteString("The implementation should satisfy these criteria:\n")
-		for _, c := range task.VerificationCriteria {
-			sb.WriteString(fmt.Sprintf("- %s\n", c))
-		}
-		sb.WriteString("\n")
+	if n.desktopPerWorktree && strings.HasPrefix(ev.Event, "worktree_") {
+		desktopNotify("autom8: "+strings.ReplaceAll(ev.Event, "_", " "), fmt.Sprintf("%s - %s", ev.Worktree, truncate(ev.Task.Prompt, 80)))
+	}
+	if n.url == "" {
+		return
 	}
 
-	sb.WriteString("## Current State\n\n")
-	sb.WriteString(fmt.Sprintf("- **Worktree:** %s\n", worktreeName))
-	sb.WriteString(fmt.Sprintf("- **Branch:** %s\n", branchName))
-	sb.WriteString(fmt.Sprintf("- **Task ID:** %s\n\n", task.ID))
+	payload := newNotificationPayload(ev)
 
-	if gitLog != "" {
-		sb.WriteString("## Commits Since Main\n\n")
-		sb.WriteString("These commits have been made in this worktree:\n\n")
-		sb.WriteString("```\n")
-		sb.WriteString(gitLog)
-		sb.WriteString("```\n\n")
+	var body []byte
+	var err error
+	if n.template != nil {
+		var buf bytes.Buffer
+		err = n.template.Execute(&buf, payload)
+		body = buf.Bytes()
 	} else {
-		sb.WriteString("## Commits Since Main\n\n")
-		sb.WriteString("No commits have been made yet in this worktree.\n\n")
+		body, err = json.Marshal(payload)
 	}
-
-	if gitDiff != "" {
-		// Truncate very large diffs to avoid overwhelming the context
-		diff := gitDiff
-		if len(diff) > 50000 {
-			diff = diff[:50000] + "\n... (diff truncated due to size)"
-		}
-		sb.WriteString("## Current Diff from Main\n\n")
-		sb.WriteString("```diff\n")
-		sb.WriteString(diff)
-		sb.WriteString("```\n\n")
-	} else {
-		sb.WriteString("## Current Diff from Main\n\n")
-		sb.WriteString("No changes from main yet.\n\n")
+	if err == nil {
+		err = postWebhook(n.url, body)
+	}
+	if err != nil {
+		n.progress.println(fmt.Sprintf("%s could not send %s notification: %v", errorStyle.Render("Warning:"), ev.Event, err))
 	}
-
-	sb.WriteString("## Your Role\n\n")
-	sb.WriteString("Help the user with this implementation. They may:\n")
-	sb.WriteString("- Ask questions about what has been implemented\n")
-	sb.WriteString("- Request explanations of the code changes\n")
-	sb.WriteString("- Give instructions to continue or fix the implementation\n")
-	sb.WriteString("- Ask you to make specific changes\n\n")
-	sb.WriteString("You have full access to the codebase in this worktree. Feel free to read files, make edits, and run commands as needed.\n")
-
-	return sb.String()
 }
 
-func runDescribe(cmd *cobra.Command, args []string) error {
-	taskID := args[0]
+// finish shows the end-of-run desktop notification when enabled.
+func (n *notifier) finish(title, message string) {
+	if n == nil || !n.desktop {
+		return
+	}
+	desktopNotify(title, message)
+}
 
-	if _, err := getGitRoot(); err != nil {
-		return err
+// desktopNotify shows a native notification with notify-send (Linux) or
+// osascript (macOS). A missing helper is silently ignored.
+func desktopNotify(title, message string) {
+	var notifyCmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		notifyCmd = exec.Command("osascript", "-e", script)
+	default:
+		notifyCmd = exec.Command("notify-send", "--app-name=autom8", title, message)
+	}
+	if notifyCmd.Err != nil {
+		return
 	}
+	notifyCmd.Run()
+}
 
-	tasks, err := loadTasks()
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// runHook runs the hook configured for ev.Event, if any. Output is appended
+// to hook-<event>.log in the worktree's log directory (or logs/hooks). It
+// returns an error if the hook fails or times out.
+func runHook(ev notification, result string) error {
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
+		return fmt.Errorf("error loading config: %w", err)
 	}
-
-	// Find the task
-	var task *Task
-	for i := range tasks {
-		if tasks[i].ID == taskID {
-			task = &tasks[i]
-			break
-		}
+	command := cfg.Hooks.command(ev.Event)
+	if command == "" {
+		return nil
 	}
 
-	if task == nil {
-		return fmt.Errorf("task '%s' not found\nRun 'autom8 status' to see task IDs", taskID)
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
 	}
-
-	// Build task map for dependency lookup
-	taskMap := make(map[string]Task)
-	for _, t := range tasks {
-		taskMap[t.ID] = t
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return err
 	}
 
-	// Find dependent tasks
-	var dependents []string
-	for _, t := range tasks {
-		if t.DependsOn == taskID {
-			dependents = append(dependents, t.ID)
-		}
-	}
+	timeout := cfg.Hooks.timeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	// Get worktrees for this task
-	autom8Path, _ := getAutom8Dir()
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	var worktrees []WorktreeInfo
-	pids, _ := loadPids()
+	payload, err := json.Marshal(newNotificationPayload(ev))
+	if err != nil {
+		return err
+	}
 
-	if entries, err := os.ReadDir(worktreesDir); err == nil {
-		for _, entry := range entries {
-			if !entry.IsDir() {
-				continue
-			}
-			worktreeName := entry.Name()
-			// Extract task ID: task-{timestamp}-{instance} -> task-{timestamp}
-			wtTaskID := worktreeName
-			if lastDash := strings.LastIndex(worktreeName, "-"); lastDash > 0 {
-				wtTaskID = worktreeName[:lastDash]
-			}
-			if wtTaskID == taskID {
-				info := getWorktreeInfo(worktreesDir, worktreeName, pids)
-				worktrees = append(worktrees, info)
+	hookCmd := hookShellCommand(ctx, command)
+	hookCmd.Dir = gitRoot
+	hookCmd.Env = append(os.Environ(),
+		"AUTOM8_EVENT="+ev.Event,
+		"AUTOM8_TASK_ID="+ev.Task.ID,
+		"AUTOM8_WORKTREE="+ev.Worktree,
+		"AUTOM8_RESULT="+result,
+	)
+	hookCmd.Stdin = bytes.NewReader(payload)
+	hookCmd.WaitDelay = time.Second
+	output, runErr := hookCmd.CombinedOutput()
+
+	logsDir := filepath.Join(autom8Path, "logs", "hooks")
+	if ev.Worktree != "" {
+		logsDir = filepath.Join(autom8Path, "logs", ev.Worktree)
+	}
+	logFile := filepath.Join(logsDir, fmt.Sprintf("hook-%s.log", ev.Event))
+	if err := os.MkdirAll(logsDir, 0755); err == nil {
+		if f, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err == nil {
+			fmt.Fprintf(f, "=== %s: %s\n%s", time.Now().Format(time.RFC3339), command, output)
+			if runErr != nil {
+				fmt.Fprintf(f, "ERROR: %v\n", runErr)
 			}
+			f.Close()
 		}
 	}
 
-	// Display task information
-	fmt.Println(titleStyle.Render("Task Details"))
-	fmt.Println()
-
-	// Status badge
-	var statusBadge string
-	switch task.Status {
-	case "pending":
-		statusBadge = statusPendingStyle.Render("[pending]")
-	case "in-progress":
-		statusBadge = statusInProgressStyle.Render("[in-progress]")
-	case "completed":
-		statusBadge = statusCompletedStyle.Render("[completed]")
-	default:
-		statusBadge = subtitleStyle.Render(fmt.Sprintf("[%s]", task.Status))
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s hook timed out after %s (see %s)", ev.Event, timeout, logFile)
 	}
+	if runErr != nil {
+		return fmt.Errorf("%s hook failed: %w (see %s)", ev.Event, runErr, logFile)
+	}
+	return nil
+}
 
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Status:"), statusBadge)
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("Created:"), task.CreatedAt.Format("2006-01-02 15:04:05"))
-	fmt.Println()
+func postWebhook(webhookURL string, body []byte) error {
+	return postJSON(webhookURL, body, nil, 10*time.Second)
+}
 
-	// Prompt (full, not truncated)
-	fmt.Println(subtitleStyle.Render("  Prompt:"))
-	for _, line := range strings.Split(task.Prompt, "\n") {
-		fmt.Printf("    %s\n", line)
-	}
-	fmt.Println()
+// completionPayload is the body of the accept completion webhook
+type completionPayload struct {
+	TaskID      string    `json:"task_id"`
+	Status      string    `json:"status"`
+	Worktree    string    `json:"worktree"`
+	Prompt      string    `json:"prompt"`
+	CompletedAt time.Time `json:"completed_at"`
+}
 
-	// Verification criteria
-	if len(task.VerificationCriteria) > 0 {
-		fmt.Println(subtitleStyle.Render("  Verification Criteria:"))
-		for i, c := range task.VerificationCriteria {
-			fmt.Printf("    %d. %s\n", i+1, c)
-		}
-		fmt.Println()
+// sendCompletionWebhook posts a merged task to --webhook-url, or else the
+// webhook_url config key. Failures are reported on stderr and never fail the
+// accept.
+func sendCompletionWebhook(task Task, worktree string) {
+	cfg, _ := loadConfig()
+	url := webhookURL
+	if url == "" {
+		url = cfg.WebhookURL
 	}
-
-	// Dependencies
-	if task.DependsOn != "" {
-		parentTask := taskMap[task.DependsOn]
-		fmt.Println(subtitleStyle.Render("  Depends On:"))
-		fmt.Printf("    %s - %s\n", idStyle.Render(task.DependsOn), truncate(parentTask.Prompt, 50))
-		fmt.Println()
+	if url == "" {
+		return
 	}
 
-	// Dependent tasks
-	if len(dependents) > 0 {
-		fmt.Println(subtitleStyle.Render("  Dependents:"))
-		for _, depID := range dependents {
-			depTask := taskMap[depID]
-			fmt.Printf("    %s - %s\n", idStyle.Render(depID), truncate(depTask.Prompt, 50))
-		}
-		fmt.Println()
+	headers := make(map[string]string)
+	for k, v := range cfg.WebhookHeaders {
+		headers[k] = v
 	}
-
-	// Worktrees
-	if len(worktrees) > 0 {
-		fmt.Println(subtitleStyle.Render("  Worktrees:"))
-		for _, wt := range worktrees {
-			var wtStatus string
-			if wt.IsRunning {
-				wtStatus = statusInProgressStyle.Render("[running]")
-			} else if wt.HasChanges {
-				wtStatus = statusPendingStyle.Render("[modified]")
-			} else if wt.CommitsAhead != "0" {
-				wtStatus = statusCompletedStyle.Render("[" + wt.CommitsAhead + " commits]")
-			} else {
-				wtStatus = subtitleStyle.Render("[idle]")
-			}
-			fmt.Printf("    %s %s\n", wtStatus, wt.Name)
-			fmt.Printf("      %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(wt.Branch))
-			fmt.Printf("      %s %s\n", subtitleStyle.Render("Path:"), wt.Path)
+	for _, h := range webhookHeaders {
+		key, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			fmt.Fprintf(os.Stderr, "%s ignoring malformed --webhook-headers '%s' (expected Key:Value)\n", errorStyle.Render("Warning:"), h)
+			continue
 		}
-	} else if task.Status == "pending" {
-		fmt.Println(subtitleStyle.Render("  Worktrees:"))
-		fmt.Println("    (none - run 'autom8 implement' to start)")
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
 	}
 
-	fmt.Println()
-	return nil
+	body, err := json.Marshal(completionPayload{
+		TaskID:      task.ID,
+		Status:      task.Status,
+		Worktree:    worktree,
+		Prompt:      task.Prompt,
+		CompletedAt: time.Now(),
+	})
+	if err == nil {
+		err = postJSON(url, body, headers, 5*time.Second)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s completion webhook failed: %v\n", errorStyle.Render("Warning:"), err)
+	}
 }
 
-func runEdit(cmd *cobra.Command, args []string) error {
-	taskID := args[0]
-
-	if _, err := getGitRoot(); err != nil {
+// postJSON posts body to url with the given extra headers.
+func postJSON(url string, body []byte, headers map[string]string, timeout time.Duration) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
 		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 
-	tasks, err := loadTasks()
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
+		return err
 	}
+	defer resp.Body.Close()
 
-	// Find the task
-	var taskIndex int = -1
-	var task *Task
-	for i := range tasks {
-		if tasks[i].ID == taskID {
-			taskIndex = i
-			task = &tasks[i]
-			break
-		}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
 	}
+	return nil
+}
 
-	if task == nil {
-		return fmt.Errorf("task '%s' not found\nRun 'autom8 status' to see task IDs", taskID)
+// headCommit returns the short hash of HEAD in dir, or "HEAD" if it cannot be resolved.
+func headCommit(dir string) string {
+	revCmd := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD")
+	output, err := revCmd.Output()
+	if err != nil {
+		return "HEAD"
 	}
+	return strings.TrimSpace(string(output))
+}
 
-	// Prepare current values for editing
-	prompt := task.Prompt
-	criteriaInput := strings.Join(task.VerificationCriteria, "\n")
-	dependsOn := task.DependsOn
+// WorktreeInfo holds information about a worktree's status
+type WorktreeInfo struct {
+	Name         string `json:"name"`
+	Path         string `json:"path"`
+	Branch       string `json:"branch"`
+	CommitsAhead string `json:"commits_ahead"`
+	HasChanges   bool   `json:"has_changes"`
+	IsRunning    bool   `json:"is_running"`
+}
 
-	// Build dependency options (exclude current task to prevent self-reference)
-	dependsOnOptions := []huh.Option[string]{
-		huh.NewOption[string]("None (independent task)", ""),
-	}
+func getWorktreeInfo(worktreesDir, worktreeName string, pids map[string]int) WorktreeInfo {
+	worktreePath := filepath.Join(worktreesDir, worktreeName)
+	info := WorktreeInfo{
+		Name: worktreeName,
+		Path: worktreePath,
+	}
+
+	// Get the branch name
+	branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
+	if branchOutput, err := branchCmd.Output(); err == nil {
+		info.Branch = strings.TrimSpace(string(branchOutput))
+	} else {
+		info.Branch = "unknown"
+	}
+
+	// Check if there are any git changes
+	statusCmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
+	if statusOutput, err := statusCmd.Output(); err == nil {
+		info.HasChanges = len(strings.TrimSpace(string(statusOutput))) > 0
+	}
+
+	// Check how many commits are ahead of the default branch (worktrees share the repo's refs)
+	aheadCmd := exec.Command("git", "-C", worktreePath, "rev-list", "--count", "HEAD", "^"+defaultBranch(worktreePath))
+	if aheadOutput, err := aheadCmd.Output(); err == nil {
+		info.CommitsAhead = strings.TrimSpace(string(aheadOutput))
+	} else {
+		info.CommitsAhead = "0"
+	}
+
+	// Check if the tracked process is still running
+	if pid, ok := pids[worktreeName]; ok {
+		info.IsRunning = isProcessRunning(pid)
+	}
+
+	return info
+}
+
+// listWorktreeNames returns the names of the worktrees in worktreesDir. The
+// directory only exists once something has been implemented, so a missing
+// one means no worktrees rather than an error.
+func listWorktreeNames(worktreesDir string) ([]string, error) {
+	entries, err := os.ReadDir(worktreesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// completeTaskIDs completes a command's task ID argument, showing each
+// task's prompt as the description.
+func completeTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return taskIDCandidates(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorktreeNames completes a command's worktree name argument. Later
+// arguments, like export's output file, fall back to file completion.
+func completeWorktreeNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return worktreeNameCandidates(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorktreesAndTasks completes arguments that take a worktree name or
+// a task ID, leaving out the ones already given.
+func completeWorktreesAndTasks(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	given := make(map[string]bool)
+	for _, arg := range args {
+		given[arg] = true
+	}
+	var candidates []string
+	for _, c := range append(worktreeNameCandidates(toComplete), taskIDCandidates(toComplete)...) {
+		if !given[strings.SplitN(c, "\t", 2)[0]] {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// taskIDCandidates returns the task IDs starting with prefix, each with its
+// prompt as a tab-separated description.
+func taskIDCandidates(prefix string) []string {
+	tasks, err := loadTasks()
+	if err != nil {
+		return nil
+	}
+	var candidates []string
 	for _, t := range tasks {
-		if t.ID != taskID { // Can't depend on itself
-			label := fmt.Sprintf("%s - %s", t.ID, truncate(t.Prompt, 40))
-			dependsOnOptions = append(dependsOnOptions, huh.NewOption[string](label, t.ID))
+		if strings.HasPrefix(t.ID, prefix) {
+			candidates = append(candidates, t.ID+"\t"+truncate(t.Prompt, 50))
 		}
 	}
+	return candidates
+}
 
-	// Interactive editing with huh
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewText().
-				Title("Task Prompt").
-				Description("What should the AI implement?").
-				Value(&prompt).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("prompt cannot be empty")
-					}
-					return nil
-				}),
-		),
-		huh.NewGroup(
-			huh.NewText().
-				Title("Verification Criteria").
-				Description("How should success be verified? (one per line, optional)").
-				Value(&criteriaInput),
-		),
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Depends On").
-				Description("Select a task this depends on (optional)").
-				Options(dependsOnOptions...).
-				Value(&dependsOn),
-		),
-	).WithTheme(huh.ThemeDracula())
+// worktreeNameCandidates returns the worktree names starting with prefix.
+func worktreeNameCandidates(prefix string) []string {
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return nil
+	}
+	names, _ := listWorktreeNames(filepath.Join(autom8Path, "worktrees"))
+	var candidates []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name)
+		}
+	}
+	return candidates
+}
 
-	err = form.Run()
+// loadWorktreesByTask scans the worktrees directory and groups worktree
+// info by task ID. A missing directory yields an empty map.
+func loadWorktreesByTask() map[string][]WorktreeInfo {
+	worktreesByTask := make(map[string][]WorktreeInfo)
+
+	autom8Path, err := getAutom8Dir()
 	if err != nil {
-		if err == huh.ErrUserAborted {
-			fmt.Println("\nAborted. No changes made.")
-			return nil
+		return worktreesByTask
+	}
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	pids, _ := loadPids()
+
+	names, _ := listWorktreeNames(worktreesDir)
+	for _, worktreeName := range names {
+		taskID := parseTaskIDFromWorktreeName(worktreeName)
+		info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+		worktreesByTask[taskID] = append(worktreesByTask[taskID], info)
+	}
+
+	return worktreesByTask
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if statusOutput != "text" && statusOutput != "json" {
+		return fmt.Errorf("invalid --output '%s' (want text or json)", statusOutput)
+	}
+	if pageSize < 0 {
+		return fmt.Errorf("invalid --page-size %d: use a positive number, or 0 for no paging", pageSize)
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	if epicFlag != "" {
+		var inEpic []Task
+		for _, t := range tasks {
+			if t.Epic == epicFlag {
+				inEpic = append(inEpic, t)
+			}
 		}
-		return err
+		tasks = inEpic
 	}
 
-	// Parse criteria from multiline input
-	var criteria []string
-	if strings.TrimSpace(criteriaInput) != "" {
-		for _, line := range strings.Split(criteriaInput, "\n") {
-			line = strings.TrimSpace(line)
-			if line != "" {
-				criteria = append(criteria, line)
+	if statusOutput == "json" {
+		var v any = tasks
+		if tasks == nil {
+			v = []Task{}
+		}
+		if cmd.CalledAs() != "list" && cmd.CalledAs() != "ls" {
+			v = buildStatusTree(tasks, loadWorktreesByTask())
+		}
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	worktreesByTask := loadWorktreesByTask()
+	stats, _ := loadWorktreeStats()
+
+	if len(tasks) == 0 && epicFlag != "" {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("No tasks in epic '%s'. Add one with 'autom8 new --epic %s'.", epicFlag, epicFlag)))
+		return nil
+	}
+	if len(tasks) == 0 {
+		fmt.Println(subtitleStyle.Render("No tasks found. Use 'autom8 new' to create one."))
+		return nil
+	}
+
+	// Worktrees waiting for an agent slot don't exist on disk yet
+	queuedByTask := make(map[string][]string)
+	queuePosition := make(map[string]int)
+	if jobs, err := loadQueue(); err == nil {
+		for _, job := range jobs {
+			if job.StartedAt == nil {
+				queuedByTask[job.TaskID] = append(queuedByTask[job.TaskID], job.Worktree)
+				queuePosition[job.Worktree] = len(queuePosition) + 1
 			}
 		}
 	}
 
-	// Validate dependency exists if specified
-	if dependsOn != "" && dependsOn != task.DependsOn {
-		found := false
-		for _, t := range tasks {
-			if t.ID == dependsOn {
-				found = true
-				break
+	// Build dependency tree
+	taskMap := make(map[string]Task)
+	childrenMap := make(map[string][]string) // parent ID -> child IDs
+	var rootTasks []string
+
+	for _, t := range tasks {
+		taskMap[t.ID] = t
+	}
+	for _, t := range tasks {
+		// With --epic, a parent outside the epic isn't shown
+		if _, ok := taskMap[t.DependsOn]; !ok {
+			rootTasks = append(rootTasks, t.ID)
+		} else {
+			childrenMap[t.DependsOn] = append(childrenMap[t.DependsOn], t.ID)
+		}
+	}
+
+	if epicFlag != "" {
+		fmt.Println(titleStyle.Render("Status: " + epicFlag))
+	} else {
+		fmt.Println(titleStyle.Render("Status"))
+	}
+	if countsFlag {
+		fmt.Println(formatStatusCounts(tasks))
+	}
+	fmt.Println()
+
+	// Epics cut across the dependency tree, so they get their own summary
+	if epicFlag == "" {
+		if epics := formatEpicGroups(tasks); epics != "" {
+			fmt.Println(epics)
+			fmt.Println()
+		}
+	}
+
+	// Print tree recursively
+	var printTask func(taskID string, prefix string, isLast bool)
+	printTask = func(taskID string, prefix string, isLast bool) {
+		task := taskMap[taskID]
+
+		// Tree branch characters
+		branch := "├── "
+		if isLast {
+			branch = "└── "
+		}
+		childPrefix := prefix + "│   "
+		if isLast {
+			childPrefix = prefix + "    "
+		}
+
+		// Print task header
+		fmt.Printf("%s%s%s %s\n", prefix, branch, taskStatusBadge(task), truncate(task.Prompt, 50))
+		if !hideIDsFlag {
+			fmt.Printf("%s%s %s\n", childPrefix, subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
+		}
+		if task.Epic != "" && epicFlag == "" {
+			fmt.Printf("%s%s %s\n", childPrefix, subtitleStyle.Render("Epic:"), task.Epic)
+		}
+
+		// Print verification criteria
+		if len(task.VerificationCriteria) > 0 {
+			fmt.Printf("%s%s\n", childPrefix, subtitleStyle.Render("Criteria:"))
+			for _, c := range task.VerificationCriteria {
+				fmt.Printf("%s  • %s\n", childPrefix, c)
 			}
 		}
-		if !found {
-			return fmt.Errorf("dependency task '%s' not found", dependsOn)
+
+		// Print worktrees for this task
+		worktrees := worktreesByTask[task.ID]
+		queued := queuedByTask[task.ID]
+		children := childrenMap[task.ID]
+		hasMore := len(children) > 0
+
+		if len(worktrees) > 0 || len(queued) > 0 {
+			fmt.Printf("%s%s\n", childPrefix, subtitleStyle.Render("Worktrees:"))
+			for i, wt := range worktrees {
+				wtIsLast := i == len(worktrees)-1 && len(queued) == 0 && !hasMore
+				wtBranch := "├── "
+				if wtIsLast {
+					wtBranch = "└── "
+				}
+
+				// Worktree status
+				var wtStatus string
+				if wt.IsRunning {
+					wtStatus = statusInProgressStyle.Render("[running]")
+					if activity := lastActivity(wt); activity != "" {
+						wtStatus += " " + subtitleStyle.Render("("+activity+")")
+					}
+				} else if wt.HasChanges {
+					wtStatus = statusPendingStyle.Render("[modified]")
+				} else if wt.CommitsAhead != "0" {
+					wtStatus = statusCompletedStyle.Render("[" + wt.CommitsAhead + " commits]")
+				} else {
+					wtStatus = subtitleStyle.Render("[idle]")
+				}
+
+				// Converge's pick and scores
+				if wt.Name == task.Winner {
+					badge := "[winner]"
+					if score, ok := task.WorktreeScores[wt.Name]; ok {
+						badge = fmt.Sprintf("[winner %d/10]", score)
+					}
+					wtStatus += " " + highlightStyle.Render(badge)
+				} else if score, ok := task.WorktreeScores[wt.Name]; ok {
+					wtStatus += " " + subtitleStyle.Render(fmt.Sprintf("[%d/10]", score))
+				}
+
+				fmt.Printf("%s%s%s %s\n", childPrefix, wtBranch, wtStatus, wt.Name)
+				wtChildPrefix := childPrefix + "│   "
+				if wtIsLast {
+					wtChildPrefix = childPrefix + "    "
+				}
+
+				// Peek at what a running agent is doing
+				if wt.IsRunning {
+					logsRoot := filepath.Join(filepath.Dir(filepath.Dir(wt.Path)), "logs")
+					if line := lastOutputLine(latestIterationLog(logsRoot, wt.Name)); line != "" {
+						fmt.Printf("%s%s %s\n", wtChildPrefix, subtitleStyle.Render("›"), subtitleStyle.Render(truncate(line, 60)))
+					}
+				}
+				for _, n := range stats[wt.Name].Notes {
+					fmt.Printf("%s%s %s\n", wtChildPrefix, subtitleStyle.Render("note:"), truncate(n.Text, 70))
+				}
+
+				// Show accept hint
+				if !wt.IsRunning && (wt.CommitsAhead != "0" || wt.HasChanges) {
+					fmt.Printf("%s%s autom8 accept %s\n", wtChildPrefix, highlightStyle.Render("→"), wt.Name)
+				}
+			}
+			for i, name := range queued {
+				wtBranch := "├── "
+				if i == len(queued)-1 && !hasMore {
+					wtBranch = "└── "
+				}
+				wtStatus := subtitleStyle.Render(fmt.Sprintf("[queued #%d]", queuePosition[name]))
+				fmt.Printf("%s%s%s %s\n", childPrefix, wtBranch, wtStatus, name)
+			}
+		} else if task.Status == "pending" && task.scheduledAfter(time.Now()) {
+			fmt.Printf("%s%s\n", childPrefix, subtitleStyle.Render("(no worktrees - cancel the schedule with 'autom8 queue cancel "+task.ID+"')"))
+		} else if task.Status == "pending" {
+			fmt.Printf("%s%s\n", childPrefix, subtitleStyle.Render("(no worktrees - run 'autom8 implement')"))
+		}
+
+		// Print children (dependent tasks)
+		for i, childID := range children {
+			printTask(childID, childPrefix, i == len(children)-1)
 		}
-		// Check for circular dependency
-		if dependsOn == taskID {
-			return fmt.Errorf("task cannot depend on itself")
+	}
+
+	// Print all root tasks. With --page-size, a task and its dependents are
+	// never split across pages; output that isn't a terminal doesn't pause
+	var input *bufio.Reader
+	pages := 1
+	if pageSize > 0 && isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+		input = bufio.NewReader(os.Stdin)
+		pages = (len(rootTasks) + pageSize - 1) / pageSize
+	}
+	for i, taskID := range rootTasks {
+		printTask(taskID, "", i == len(rootTasks)-1)
+		if i == len(rootTasks)-1 {
+			break
+		}
+		fmt.Println()
+		if input != nil && (i+1)%pageSize == 0 {
+			fmt.Print(subtitleStyle.Render(fmt.Sprintf("Page %d of %d. Press Enter for next page, q to quit ", (i+1)/pageSize, pages)))
+			line, err := input.ReadString('\n')
+			if err != nil || strings.EqualFold(strings.TrimSpace(line), "q") {
+				return nil
+			}
+			fmt.Println()
+		}
+	}
+
+	fmt.Println()
+	if legendFlag {
+		fmt.Println(formatStatusLegend())
+		fmt.Println()
+	} else if isTerminal(os.Stdout) {
+		fmt.Println(subtitleStyle.Render("Run 'autom8 status --legend' to see what the badges mean."))
+	}
+	return nil
+}
+
+// taskStatusBadge renders a task's status, plus its start time if scheduled.
+func taskStatusBadge(task Task) string {
+	var badge string
+	switch task.Status {
+	case "pending":
+		badge = statusPendingStyle.Render("[pending]")
+	case "in-progress":
+		badge = statusInProgressStyle.Render("[in-progress]")
+	case "completed":
+		badge = statusCompletedStyle.Render("[completed]")
+	default:
+		badge = subtitleStyle.Render(fmt.Sprintf("[%s]", task.Status))
+	}
+
+	if task.Status == "pending" && task.scheduledAfter(time.Now()) {
+		badge += " " + subtitleStyle.Render("[scheduled "+task.NotBefore.Format("Jan 2 15:04")+"]")
+	}
+	return badge
+}
+
+// formatEpicGroups lists the tasks of each epic, epics sorted by name, or
+// returns "" if no task belongs to an epic.
+func formatEpicGroups(tasks []Task) string {
+	byEpic := make(map[string][]Task)
+	var names []string
+	for _, t := range tasks {
+		if t.Epic == "" {
+			continue
+		}
+		if byEpic[t.Epic] == nil {
+			names = append(names, t.Epic)
+		}
+		byEpic[t.Epic] = append(byEpic[t.Epic], t)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+
+	lines := []string{subtitleStyle.Render("Epics:")}
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("  %s %s %s", highlightStyle.Render(name),
+			subtitleStyle.Render(fmt.Sprintf("(%d tasks)", len(byEpic[name]))), formatStatusCounts(byEpic[name])))
+		for _, t := range byEpic[name] {
+			line := fmt.Sprintf("    %s %s", taskStatusBadge(t), truncate(t.Prompt, 50))
+			if !hideIDsFlag {
+				line += " " + idStyle.Render(t.ID)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatStatusLegend renders task status and worktree badge explanations side by side.
+func formatStatusLegend() string {
+	column := func(title string, rows [][2]string) string {
+		width := 0
+		for _, r := range rows {
+			width = max(width, lipgloss.Width(r[0]))
+		}
+		lines := []string{subtitleStyle.Render(title)}
+		for _, r := range rows {
+			pad := strings.Repeat(" ", width-lipgloss.Width(r[0]))
+			lines = append(lines, fmt.Sprintf("  %s%s  %s", r[0], pad, r[1]))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	tasksColumn := column("Task status", [][2]string{
+		{statusPendingStyle.Render("[pending]"), "not started"},
+		{subtitleStyle.Render("[scheduled T]"), "starts at T"},
+		{statusInProgressStyle.Render("[in-progress]"), "agents working"},
+		{statusCompletedStyle.Render("[completed]"), "accepted and merged"},
+	})
+	worktreesColumn := column("Worktree badges", [][2]string{
+		{statusInProgressStyle.Render("[running]"), "agent still working"},
+		{subtitleStyle.Render("›"), "its latest line of output"},
+		{statusPendingStyle.Render("[modified]"), "uncommitted changes"},
+		{statusCompletedStyle.Render("[N commits]"), "ahead of the default branch, ready to accept"},
+		{subtitleStyle.Render("[idle]"), "no changes yet"},
+		{subtitleStyle.Render("[queued #N]"), "waiting for an agent slot"},
+		{highlightStyle.Render("[winner S/10]"), "converge's pick and its score"},
+		{subtitleStyle.Render("[S/10]"), "converge score of the others"},
+		{highlightStyle.Render("→"), "command to accept it"},
+	})
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().MarginRight(4).Render(tasksColumn), worktreesColumn)
+}
+
+// formatStatusCounts renders e.g. "pending: 5, in-progress: 2, completed: 8".
+// Known statuses always appear in lifecycle order; any others follow alphabetically.
+func formatStatusCounts(tasks []Task) string {
+	counts := make(map[string]int)
+	for _, t := range tasks {
+		counts[t.Status]++
+	}
+
+	parts := []string{
+		statusPendingStyle.Render(fmt.Sprintf("pending: %d", counts["pending"])),
+		statusInProgressStyle.Render(fmt.Sprintf("in-progress: %d", counts["in-progress"])),
+		statusCompletedStyle.Render(fmt.Sprintf("completed: %d", counts["completed"])),
+	}
+
+	var others []string
+	for status := range counts {
+		switch status {
+		case "pending", "in-progress", "completed":
+		default:
+			others = append(others, status)
+		}
+	}
+	sort.Strings(others)
+	for _, status := range others {
+		parts = append(parts, subtitleStyle.Render(fmt.Sprintf("%s: %d", status, counts[status])))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func runQueue(cmd *cobra.Command, args []string) error {
+	jobs, err := loadQueue()
+	if err != nil {
+		return fmt.Errorf("error loading queue: %w", err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	limit := "unlimited"
+	if cfg.Queue.MaxAgents > 0 {
+		limit = strconv.Itoa(cfg.Queue.MaxAgents)
+	}
+
+	fmt.Println(titleStyle.Render("Queue"))
+	fmt.Printf("  %s %s (queue.max_agents)\n", subtitleStyle.Render("Agent slots:"), limit)
+	fmt.Println()
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+	var scheduled []Task
+	for _, t := range tasks {
+		if t.Status == "pending" && t.scheduledAfter(time.Now()) {
+			scheduled = append(scheduled, t)
+		}
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool { return scheduled[i].NotBefore.Before(scheduled[j].NotBefore) })
+
+	if len(jobs) == 0 && len(scheduled) == 0 {
+		fmt.Println(subtitleStyle.Render("Nothing running, queued or scheduled."))
+		return nil
+	}
+
+	position := 0
+	for _, job := range jobs {
+		if job.StartedAt != nil {
+			fmt.Printf("  %s %s %s\n", statusInProgressStyle.Render("[running]"), job.Worktree,
+				subtitleStyle.Render(fmt.Sprintf("(%s, pid %d)", time.Since(*job.StartedAt).Round(time.Second), job.PID)))
+		}
+	}
+	for _, job := range jobs {
+		if job.StartedAt == nil {
+			position++
+			fmt.Printf("  %s %s %s\n", subtitleStyle.Render(fmt.Sprintf("[queued #%d]", position)), job.Worktree,
+				subtitleStyle.Render(fmt.Sprintf("(waiting %s, pid %d)", time.Since(job.QueuedAt).Round(time.Second), job.PID)))
+		}
+	}
+	for _, t := range scheduled {
+		fmt.Printf("  %s %s %s\n", subtitleStyle.Render("[scheduled "+t.NotBefore.Format("Jan 2 15:04")+"]"), idStyle.Render(t.ID),
+			subtitleStyle.Render(fmt.Sprintf("(in %s) %s", time.Until(t.NotBefore).Round(time.Minute), truncate(t.Prompt, 40))))
+	}
+	return nil
+}
+
+func runQueueMove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	position, err := strconv.Atoi(args[1])
+	if err != nil || position < 1 {
+		return fmt.Errorf("invalid position '%s': use a number from 1", args[1])
+	}
+
+	err = updateQueue(func(jobs []queuedJob) ([]queuedJob, error) {
+		var running, queued []queuedJob
+		var job *queuedJob
+		for _, j := range jobs {
+			switch {
+			case j.Worktree == name && j.StartedAt != nil:
+				return nil, fmt.Errorf("worktree '%s' is already running", name)
+			case j.Worktree == name:
+				job = &j
+			case j.StartedAt != nil:
+				running = append(running, j)
+			default:
+				queued = append(queued, j)
+			}
+		}
+		if job == nil {
+			return nil, fmt.Errorf("worktree '%s' is not queued\nRun 'autom8 queue' to see queued worktrees", name)
+		}
+
+		position = min(position, len(queued)+1)
+		queued = append(queued[:position-1], append([]queuedJob{*job}, queued[position-1:]...)...)
+		return append(running, queued...), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s is now #%d in the queue\n", successStyle.Render("Moved:"), name, position)
+	return nil
+}
+
+func runQueueCancel(cmd *cobra.Command, args []string) error {
+	cancel := make(map[string]bool)
+	for _, name := range args {
+		cancel[name] = true
+	}
+
+	// Task IDs cancel a scheduled run
+	unscheduled := 0
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		for i, t := range tasks {
+			if cancel[t.ID] && t.Status == "pending" && !t.NotBefore.IsZero() {
+				delete(cancel, t.ID)
+				tasks[i].NotBefore = time.Time{}
+				tasks[i].UpdatedAt = time.Now()
+				unscheduled++
+				fmt.Printf("  %s %s is no longer scheduled\n", subtitleStyle.Render("[unscheduled]"), idStyle.Render(t.ID))
+			}
+		}
+		if unscheduled == 0 {
+			return nil, nil
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating tasks: %w", err)
+	}
+	if len(cancel) == 0 {
+		return nil
+	}
+
+	remaining := make(map[string]bool) // Tasks that still have queued or running jobs
+	cancelledTasks := make(map[string]bool)
+	removed := 0
+	err = updateQueue(func(jobs []queuedJob) ([]queuedJob, error) {
+		for _, job := range jobs {
+			if cancel[job.Worktree] && job.StartedAt != nil {
+				return nil, fmt.Errorf("worktree '%s' is already running", job.Worktree)
+			}
+		}
+		kept := jobs[:0]
+		for _, job := range jobs {
+			if cancel[job.Worktree] {
+				delete(cancel, job.Worktree)
+				cancelledTasks[job.TaskID] = true
+				removed++
+				continue
+			}
+			remaining[job.TaskID] = true
+			kept = append(kept, job)
+		}
+		return kept, nil
+	})
+	if err != nil {
+		return err
+	}
+	for name := range cancel {
+		fmt.Printf("%s '%s' is neither a queued worktree nor a scheduled task\n", errorStyle.Render("Warning:"), name)
+	}
+	if len(cancelledTasks) == 0 {
+		return nil
+	}
+
+	// A task whose every job was cancelled would otherwise stay in-progress
+	worktreesByTask := loadWorktreesByTask()
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		for i, t := range tasks {
+			if t.Status == "in-progress" && cancelledTasks[t.ID] && !remaining[t.ID] && len(worktreesByTask[t.ID]) == 0 {
+				tasks[i].Status = "pending"
+				tasks[i].UpdatedAt = time.Now()
+				fmt.Printf("  %s %s returned to pending\n", subtitleStyle.Render("[reset]"), idStyle.Render(t.ID))
+			}
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating task status: %w", err)
+	}
+
+	fmt.Printf("%s %d worktree(s) removed from the queue\n", successStyle.Render("Cancelled:"), removed)
+	return nil
+}
+
+// statusNode is a task in the JSON form of the status tree.
+type statusNode struct {
+	Task
+	Worktrees []WorktreeInfo `json:"worktrees"`
+	Children  []statusNode   `json:"children"`
+}
+
+// buildStatusTree nests tasks under their parents, as status draws them.
+// Tasks whose parent is missing are listed at the top level.
+func buildStatusTree(tasks []Task, worktreesByTask map[string][]WorktreeInfo) []statusNode {
+	childrenMap := make(map[string][]Task)
+	var roots []Task
+	for _, t := range tasks {
+		if t.DependsOn == "" || findTask(tasks, t.DependsOn) == nil {
+			roots = append(roots, t)
+		} else {
+			childrenMap[t.DependsOn] = append(childrenMap[t.DependsOn], t)
+		}
+	}
+
+	var build func(ts []Task) []statusNode
+	build = func(ts []Task) []statusNode {
+		nodes := []statusNode{}
+		for _, t := range ts {
+			worktrees := worktreesByTask[t.ID]
+			if worktrees == nil {
+				worktrees = []WorktreeInfo{}
+			}
+			nodes = append(nodes, statusNode{Task: t, Worktrees: worktrees, Children: build(childrenMap[t.ID])})
+		}
+		return nodes
+	}
+	return build(roots)
+}
+
+func runStatusSet(cmd *cobra.Command, args []string) error {
+	taskID, status := args[0], args[1]
+
+	valid := false
+	for _, s := range validStatuses {
+		if s == status {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid status '%s' (expected one of: %s)", status, strings.Join(validStatuses, ", "))
+	}
+
+	var previous string
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		task := findTask(tasks, taskID)
+		if task == nil {
+			return nil, ErrTaskNotFound{ID: taskID}
+		}
+		previous = task.Status
+		if previous == status {
+			return nil, nil
+		}
+		task.Status = status
+		task.UpdatedAt = time.Now()
+		return tasks, nil
+	})
+	if err != nil {
+		return err
+	}
+	if previous == status {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Task '%s' is already %s.", taskID, status)))
+		return nil
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("Task '%s' status changed: %s -> %s", taskID, previous, status)))
+	return nil
+}
+
+// taskProblem is a single finding from validate
+type taskProblem struct {
+	fatal   bool
+	taskIDs []string
+	message string
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	problems := validateTasks(tasks)
+
+	fmt.Println(titleStyle.Render("Validating Tasks"))
+	fmt.Println()
+
+	var errorCount, warningCount int
+	for _, p := range problems {
+		label := statusPendingStyle.Render("[warning]")
+		if p.fatal {
+			label = errorStyle.Render("[error]")
+			errorCount++
+		} else {
+			warningCount++
+		}
+		ids := make([]string, len(p.taskIDs))
+		for i, id := range p.taskIDs {
+			ids[i] = idStyle.Render(id)
+		}
+		fmt.Printf("  %s %s: %s\n", label, strings.Join(ids, ", "), p.message)
+	}
+
+	if len(problems) == 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("  %d task(s), no problems found.", len(tasks))))
+		return nil
+	}
+
+	fmt.Println()
+	if errorCount > 0 {
+		return fmt.Errorf("found %d error(s) and %d warning(s)", errorCount, warningCount)
+	}
+	fmt.Println(subtitleStyle.Render(fmt.Sprintf("Found %d warning(s).", warningCount)))
+	return nil
+}
+
+// validateTasks checks the task list for integrity problems.
+func validateTasks(tasks []Task) []taskProblem {
+	var problems []taskProblem
+
+	byID := make(map[string]Task)
+	seen := make(map[string]int)
+	for _, t := range tasks {
+		seen[t.ID]++
+		if seen[t.ID] == 2 {
+			problems = append(problems, taskProblem{fatal: true, taskIDs: []string{t.ID}, message: "duplicate task ID"})
+		}
+		byID[t.ID] = t
+	}
+
+	for _, t := range tasks {
+		if strings.TrimSpace(t.ID) == "" {
+			problems = append(problems, taskProblem{fatal: true, taskIDs: []string{"(empty)"}, message: fmt.Sprintf("task has no ID (prompt: %s)", truncate(t.Prompt, 40))})
+		}
+		if t.DependsOn != "" {
+			if _, ok := byID[t.DependsOn]; !ok {
+				problems = append(problems, taskProblem{fatal: true, taskIDs: []string{t.ID}, message: fmt.Sprintf("depends on missing task '%s'", t.DependsOn)})
+			}
+		}
+
+		valid := false
+		for _, s := range validStatuses {
+			if s == t.Status {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			problems = append(problems, taskProblem{fatal: true, taskIDs: []string{t.ID}, message: fmt.Sprintf("invalid status '%s' (expected one of: %s)", t.Status, strings.Join(validStatuses, ", "))})
+		}
+
+		if strings.TrimSpace(t.Prompt) == "" {
+			problems = append(problems, taskProblem{taskIDs: []string{t.ID}, message: "empty prompt"})
+		}
+		for i, c := range t.VerificationCriteria {
+			if strings.TrimSpace(c) == "" {
+				problems = append(problems, taskProblem{taskIDs: []string{t.ID}, message: fmt.Sprintf("verification criterion %d is empty", i+1)})
+			}
+		}
+	}
+
+	// Each task has at most one parent, so following DependsOn from every
+	// task finds each cycle; report each one once.
+	reported := make(map[string]bool)
+	for _, t := range tasks {
+		var path []string
+		onPath := make(map[string]int)
+		for id := t.ID; id != "" && !reported[id]; id = byID[id].DependsOn {
+			if start, ok := onPath[id]; ok {
+				cycle := path[start:]
+				for _, c := range cycle {
+					reported[c] = true
+				}
+				problems = append(problems, taskProblem{fatal: true, taskIDs: cycle, message: "dependency cycle"})
+				break
+			}
+			if _, ok := byID[id]; !ok {
+				break
+			}
+			onPath[id] = len(path)
+			path = append(path, id)
+		}
+	}
+
+	return problems
+}
+
+// resolveAcceptTarget maps accept's argument to a worktree name. Worktree
+// names are returned as is; a task ID resolves to the task's converge
+// winner or its only worktree, or with --interactive-select to one the user
+// picks.
+func resolveAcceptTarget(autom8Path, arg string) (string, error) {
+	if _, err := os.Stat(filepath.Join(autom8Path, "worktrees", arg)); err == nil {
+		return arg, nil
+	}
+	tasks, err := loadTasks()
+	if err != nil {
+		return "", fmt.Errorf("error loading tasks: %w", err)
+	}
+	task := findTask(tasks, arg)
+	if task == nil {
+		return arg, nil
+	}
+
+	worktrees := loadWorktreesByTask()[task.ID]
+	for _, wt := range worktrees {
+		if wt.Name == task.Winner {
+			return wt.Name, nil
+		}
+	}
+	switch len(worktrees) {
+	case 0:
+		return "", fmt.Errorf("task '%s' has no worktrees\nRun 'autom8 implement %s' first", task.ID, task.ID)
+	case 1:
+		return worktrees[0].Name, nil
+	}
+
+	if !selectWorktree {
+		return "", fmt.Errorf("task '%s' has %d worktrees and no converge winner\nName one, run 'autom8 converge %s', or pick with --interactive-select", task.ID, len(worktrees), task.ID)
+	}
+	if !isTerminal(os.Stdin) {
+		return "", fmt.Errorf("--interactive-select needs a terminal")
+	}
+
+	options := make([]huh.Option[string], 0, len(worktrees))
+	for _, wt := range worktrees {
+		details := fmt.Sprintf("%s commit(s) ahead", wt.CommitsAhead)
+		if wt.HasChanges {
+			details += ", uncommitted changes"
+		}
+		if wt.IsRunning {
+			details += ", agent running"
+		}
+		options = append(options, huh.NewOption(fmt.Sprintf("%s (%s)", wt.Name, details), wt.Name))
+	}
+
+	var choice string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Accept which worktree?").
+				Description(truncate(task.Prompt, 60)).
+				Options(options...).
+				Value(&choice),
+		),
+	).WithTheme(huh.ThemeDracula())
+	if err := form.Run(); err != nil {
+		if err == huh.ErrUserAborted {
+			return "", fmt.Errorf("aborted")
+		}
+		return "", err
+	}
+	return choice, nil
+}
+
+func runAccept(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("worktree name required\nRun 'autom8 status' to see available worktrees")
+	}
+	if prFlag && commitMessage != "" {
+		return fmt.Errorf("--commit-message can't be combined with --pr, which doesn't merge locally")
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return fmt.Errorf("error getting git root: %w", err)
+	}
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+
+	worktreeName, err := resolveAcceptTarget(autom8Path, args[0])
+	if err != nil {
+		return err
+	}
+	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+
+	// Check if worktree exists
+	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	// Get the branch name from the worktree
+	branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
+	branchOutput, err := branchCmd.Output()
+	if err != nil {
+		return fmt.Errorf("error getting branch name: %w", err)
+	}
+	branchName := strings.TrimSpace(string(branchOutput))
+
+	if branchName == "" {
+		return fmt.Errorf("could not determine branch name for worktree")
+	}
+
+	// Check for uncommitted changes in the worktree
+	statusCmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
+	statusOutput, err := statusCmd.Output()
+	if err != nil {
+		return fmt.Errorf("error checking worktree status: %w", err)
+	}
+
+	if len(strings.TrimSpace(string(statusOutput))) > 0 {
+		fmt.Println(subtitleStyle.Render("Found uncommitted changes, auto-committing..."))
+
+		// Stage all changes
+		addCmd := exec.Command("git", "-C", worktreePath, "add", "-A")
+		if addOutput, err := addCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("error staging changes: %w\n%s", err, string(addOutput))
+		}
+
+		// Commit with auto-commit message
+		commitCmd := exec.Command("git", "-C", worktreePath, "commit", "-m", "autom8: auto-commit uncommitted changes")
+		if commitOutput, err := commitCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("error committing changes: %w\n%s", err, string(commitOutput))
+		}
+		fmt.Println(successStyle.Render("Auto-committed successfully."))
+	}
+
+	if verifyFlag {
+		if err := verifyBeforeAccept(worktreeName, worktreePath); err != nil {
+			return err
+		}
+	}
+
+	// Resolve the branch to merge into
+	currentCmd := exec.Command("git", "-C", gitRoot, "branch", "--show-current")
+	currentOutput, _ := currentCmd.Output()
+	currentBranch := strings.TrimSpace(string(currentOutput))
+	targetBranch := currentBranch
+	if intoFlag != "" {
+		verifyCmd := exec.Command("git", "-C", gitRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+intoFlag)
+		if err := verifyCmd.Run(); err != nil {
+			return fmt.Errorf("target branch '%s' does not exist", intoFlag)
+		}
+		targetBranch = intoFlag
+	}
+
+	if prFlag {
+		return openPullRequest(gitRoot, worktreeName, worktreePath, branchName, targetBranch)
+	}
+
+	// A previous accept stopped on conflicts: conclude that merge and go
+	// straight to cleanup
+	resumed, err := concludeConflictedMerge(gitRoot, worktreeName, branchName)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		targetBranch = currentBranch
+	} else if err := runPreAcceptHook(worktreeName, branchName); err != nil {
+		return err
+	}
+
+	// Merging into another branch happens in a temporary worktree, so the
+	// current checkout is never switched
+	mergeDir := gitRoot
+	if !resumed && targetBranch != currentBranch {
+		tmpDir, err := os.MkdirTemp("", "autom8-merge-")
+		if err != nil {
+			return fmt.Errorf("error creating temporary worktree: %w", err)
+		}
+		addCmd := exec.Command("git", "-C", gitRoot, "worktree", "add", tmpDir, targetBranch)
+		if addOutput, err := addCmd.CombinedOutput(); err != nil {
+			os.RemoveAll(tmpDir)
+			return fmt.Errorf("error checking out '%s' in a temporary worktree: %w\n%s", targetBranch, err, string(addOutput))
+		}
+		defer func() {
+			cleanupCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", "--force", tmpDir)
+			if cleanupOutput, err := cleanupCmd.CombinedOutput(); err != nil {
+				fmt.Printf("%s could not remove temporary worktree: %v\n%s", errorStyle.Render("Warning:"), err, string(cleanupOutput))
+			}
+		}()
+		mergeDir = tmpDir
+	}
+
+	if !resumed {
+		fmt.Printf("Merging branch '%s' into '%s'...\n", highlightStyle.Render(branchName), highlightStyle.Render(targetBranch))
+
+		mergeArgs := []string{"-C", mergeDir, "merge", branchName, "-m", fmt.Sprintf("Merge %s (autom8 accept)", branchName)}
+		if commitMessage != "" {
+			// A fast-forward would drop the message, so always record a merge commit
+			mergeArgs = []string{"-C", mergeDir, "merge", "--no-ff", branchName, "-m", commitMessage}
+		}
+		mergeCmd := exec.Command("git", mergeArgs...)
+		mergeOutput, err := mergeCmd.CombinedOutput()
+		if err != nil {
+			conflicts := conflictedFiles(mergeDir)
+			if mergeDir != gitRoot {
+				abortMerge(mergeDir)
+				return fmt.Errorf("error merging branch into '%s': %w\n%s%s\nThe merge was aborted and '%s' is unchanged; check it out and run 'autom8 accept --keep-conflicts' to resolve conflicts there",
+					targetBranch, err, string(mergeOutput), conflictGuidance(conflicts), targetBranch)
+			}
+			if len(conflicts) > 0 && keepConflicts {
+				return fmt.Errorf("merge stopped on conflicts\n%s\nResolve them and 'git add' the files, then run 'autom8 accept %s' again to finish the merge and clean up\n(or 'git merge --abort' to back out)",
+					conflictGuidance(conflicts), worktreeName)
+			}
+			if abortErr := abortMerge(mergeDir); abortErr != nil {
+				return fmt.Errorf("error merging branch: %w\n%s%s\n%v", err, string(mergeOutput), conflictGuidance(conflicts), abortErr)
+			}
+			if len(conflicts) == 0 {
+				return fmt.Errorf("error merging branch: %w\n%s", err, string(mergeOutput))
+			}
+			return fmt.Errorf("merge stopped on conflicts\n%s\nThe merge was aborted and '%s' is unchanged; run 'autom8 accept %s --keep-conflicts' to resolve them in place",
+				conflictGuidance(conflicts), targetBranch, worktreeName)
+		}
+		fmt.Printf("%s", string(mergeOutput))
+	}
+	mergeCommit := headCommit(mergeDir)
+
+	if keepWorktreeFlag {
+		fmt.Printf("Keeping worktree '%s'.\n", worktreeName)
+		if !keepBranchFlag {
+			// A branch can't be deleted while checked out, so detach the worktree from it
+			detachCmd := exec.Command("git", "-C", worktreePath, "checkout", "--detach")
+			if detachOutput, err := detachCmd.CombinedOutput(); err != nil {
+				return fmt.Errorf("error detaching worktree from branch: %w\n%s", err, string(detachOutput))
+			}
+		}
+	} else {
+		// Remove the worktree
+		leaveWorktree(worktreePath, gitRoot)
+		fmt.Printf("Removing worktree '%s'...\n", worktreeName)
+		removeCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", worktreePath)
+		removeOutput, err := removeCmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("error removing worktree: %w\n%s\nYou may need to manually remove it with: git worktree remove %s", err, string(removeOutput), worktreePath)
+		}
+	}
+
+	if keepBranchFlag {
+		fmt.Printf("Keeping branch '%s'.\n", branchName)
+	} else {
+		// Delete the branch (it's been merged). git branch -d only recognizes
+		// merges into HEAD, so force it when the merge went elsewhere.
+		fmt.Printf("Deleting branch '%s'...\n", branchName)
+		deleteFlag := "-d"
+		if mergeDir != gitRoot {
+			deleteFlag = "-D"
+		}
+		deleteBranchCmd := exec.Command("git", "-C", gitRoot, "branch", deleteFlag, branchName)
+		deleteBranchOutput, err := deleteBranchCmd.CombinedOutput()
+		if err != nil {
+			fmt.Printf("%s could not delete branch: %v\n%s\n", errorStyle.Render("Warning:"), err, string(deleteBranchOutput))
+			fmt.Println("The branch may need to be deleted manually with: git branch -D", branchName)
+		}
+	}
+
+	// Mark the task as completed
+	taskID := parseTaskIDFromWorktreeName(worktreeName)
+
+	issues := newIssueSync(gitRoot)
+
+	var accepted *Task
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		task := findTask(tasks, taskID)
+		if task == nil {
+			return nil, nil
+		}
+		task.Status = "completed"
+		task.UpdatedAt = time.Now()
+		t := *task
+		accepted = &t
+		return tasks, nil
+	})
+	if err != nil {
+		fmt.Printf("%s could not save task status: %v\n", errorStyle.Render("Warning:"), err)
+	} else if accepted != nil {
+		fmt.Printf("Marked task '%s' as completed.\n", taskID)
+	}
+	if accepted != nil {
+		issues.close(*accepted, fmt.Sprintf("Merged `%s` in %s (autom8 accept).", branchName, mergeCommit))
+		acceptEvent := notification{Event: "accept_merged", Task: *accepted, Worktree: worktreeName, Duration: time.Since(accepted.CreatedAt)}
+		newNotifier(nil).send(acceptEvent)
+		sendCompletionWebhook(*accepted, worktreeName)
+		if err := runHook(acceptEvent, mergeCommit); err != nil {
+			fmt.Printf("%s %v\n", errorStyle.Render("Warning:"), err)
+		}
+	}
+
+	issues.flush()
+
+	if strings.HasPrefix(taskID, tempTaskPrefix) {
+		offerSaveTempTask(autom8Path, worktreeName)
+	}
+
+	pruneSiblingWorktrees(gitRoot, taskID, worktreeName)
+
+	pushAfterAccept(gitRoot, targetBranch)
+
+	fmt.Println()
+	fmt.Println(successStyle.Render(fmt.Sprintf("Successfully accepted worktree '%s'", worktreeName)))
+
+	startDependents([]string{taskID}, targetBranch)
+	return nil
+}
+
+// pruneSiblingWorktrees removes the accepted task's other worktrees and
+// their branches if --prune-siblings or accept.prune_siblings is set, after
+// confirmation unless --yes. Worktrees with a running agent are left alone.
+func pruneSiblingWorktrees(gitRoot, taskID, accepted string) {
+	enabled := pruneSiblings
+	if !enabled {
+		cfg, err := loadConfig()
+		enabled = err == nil && cfg.Accept.PruneSiblings
+	}
+	if !enabled {
+		return
+	}
+
+	var siblings []WorktreeInfo
+	for _, wt := range loadWorktreesByTask()[taskID] {
+		if wt.Name == accepted {
+			continue
+		}
+		if wt.IsRunning {
+			fmt.Printf("%s keeping %s, its agent is still running\n", subtitleStyle.Render("Note:"), wt.Name)
+			continue
+		}
+		siblings = append(siblings, wt)
+	}
+	if len(siblings) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("%s %d other worktree(s) of %s:\n", subtitleStyle.Render("Pruning"), len(siblings), idStyle.Render(taskID))
+	for _, wt := range siblings {
+		fmt.Printf("  %s (%s commit(s) ahead)\n", wt.Name, wt.CommitsAhead)
+	}
+
+	if !yesFlag {
+		if !isTerminal(os.Stdin) {
+			fmt.Printf("%s not pruning without confirmation; pass --yes to prune non-interactively\n", subtitleStyle.Render("Note:"))
+			return
+		}
+		var confirmed bool
+		err := huh.NewConfirm().
+			Title(fmt.Sprintf("Remove these %d worktree(s) and their branches?", len(siblings))).
+			Affirmative("Remove").
+			Negative("Keep").
+			Value(&confirmed).
+			WithTheme(huh.ThemeDracula()).
+			Run()
+		if err != nil || !confirmed {
+			fmt.Println("Kept the other worktrees.")
+			return
+		}
+	}
+
+	var removed int
+	for _, wt := range siblings {
+		if removeWorktreeAndBranch(gitRoot, wt.Path) {
+			removed++
+		} else {
+			fmt.Printf("%s could not remove worktree '%s'\n", errorStyle.Render("Warning:"), wt.Name)
+		}
+	}
+	fmt.Printf("Removed %d worktree(s).\n", removed)
+}
+
+// dependentLevel counts the nested runs started by startDependents.
+var dependentLevel int
+
+// startDependents implements the pending tasks that depend on the just-merged
+// tasks, branching from base (HEAD if empty), if --start-dependents or
+// accept.start_dependents is set. It chains one level: dependents merged by
+// the run it starts (implement.auto_converge: merge) only start their own
+// dependents with --cascade. Failures are reported, not returned, since the
+// merge itself succeeded.
+func startDependents(parentIDs []string, base string) {
+	enabled := startDepsFlag
+	if !enabled {
+		cfg, err := loadConfig()
+		enabled = err == nil && cfg.Accept.StartDependents
+	}
+	if !enabled || len(parentIDs) == 0 {
+		return
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		fmt.Printf("%s could not load tasks to start dependents: %v\n", errorStyle.Render("Warning:"), err)
+		return
+	}
+	isParent := make(map[string]bool)
+	for _, id := range parentIDs {
+		isParent[id] = true
+	}
+	var dependents []Task
+	var ids []string
+	for _, t := range tasks {
+		if t.Status == "pending" && isParent[t.DependsOn] {
+			dependents = append(dependents, t)
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(dependents) == 0 {
+		return
+	}
+
+	fmt.Println()
+	if dependentLevel > 0 && !cascadeFlag {
+		fmt.Printf("%s not starting %d dependent task(s) automatically: that would chain more than one level (use --cascade, or 'autom8 implement')\n",
+			subtitleStyle.Render("Note:"), len(dependents))
+		return
+	}
+	from := base
+	if from == "" {
+		from = "the current branch"
+	}
+	fmt.Printf("%s %d dependent task(s) from %s:\n", successStyle.Render("Starting"), len(dependents), highlightStyle.Render(from))
+	for _, t := range dependents {
+		fmt.Printf("  %s %s\n", idStyle.Render(t.ID), truncate(t.Prompt, 50))
+	}
+	fmt.Println()
+
+	dependentLevel++
+	defer func() { dependentLevel-- }()
+	outcomes, err := implementTasks(ids, base)
+	if err != nil {
+		fmt.Printf("%s starting dependents failed: %v\n", errorStyle.Render("Warning:"), err)
+	} else if outcomes != nil {
+		if err := outcomes.checkFailures(allowFailures); err != nil {
+			fmt.Printf("%s %v\n", errorStyle.Render("Warning:"), err)
+		}
+	}
+}
+
+// offerSaveTempTask asks whether to keep the prompt of an accepted
+// --stdin-prompt run as a completed task.
+func offerSaveTempTask(autom8Path, worktreeName string) {
+	data, err := os.ReadFile(filepath.Join(autom8Path, "logs", worktreeName, tempTaskFile))
+	if err != nil {
+		return
+	}
+	var task Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return
+	}
+
+	if !isTerminal(os.Stdin) {
+		fmt.Println(subtitleStyle.Render("Temporary task was not saved (no terminal to confirm)."))
+		return
+	}
+
+	var save bool
+	err = huh.NewConfirm().
+		Title("Save this prompt as a task?").
+		Description(truncate(task.Prompt, 70)).
+		Affirmative("Save").
+		Negative("Discard").
+		Value(&save).
+		WithTheme(huh.ThemeDracula()).
+		Run()
+	if err != nil || !save {
+		return
+	}
+
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		task.ID = newTaskID(tasks)
+		task.Status = "completed"
+		task.UpdatedAt = time.Now()
+		return append(tasks, task), nil
+	})
+	if err != nil {
+		fmt.Printf("%s could not save task: %v\n", errorStyle.Render("Warning:"), err)
+		return
+	}
+	fmt.Printf("Saved as task %s.\n", idStyle.Render(task.ID))
+}
+
+// conflictedFiles lists files with unresolved merge conflicts in dir.
+func conflictedFiles(dir string) []string {
+	diffCmd := exec.Command("git", "-C", dir, "diff", "--name-only", "--diff-filter=U")
+	output, err := diffCmd.Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(output))
+}
+
+// conflictGuidance lists conflicted files with a hint for resolving each.
+func conflictGuidance(files []string) string {
+	if len(files) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nConflicted files (%d):\n", len(files)))
+	for _, file := range files {
+		sb.WriteString(fmt.Sprintf("  %s\n    %s\n", file, conflictHint(file)))
+	}
+	return sb.String()
+}
+
+// conflictHint suggests how to resolve a conflict based on the kind of file.
+func conflictHint(file string) string {
+	switch base := filepath.Base(file); {
+	case base == "go.sum":
+		return "take either side, then run 'go mod tidy' to regenerate it"
+	case base == "package-lock.json" || base == "yarn.lock" || base == "pnpm-lock.yaml" || base == "Cargo.lock" || base == "flake.lock":
+		return "lock file: take either side and regenerate it with the package manager instead of hand-merging"
+	case strings.HasSuffix(base, ".json"):
+		return "JSON: consider using jq to merge these (e.g. jq -s '.[0] * .[1]' ours.json theirs.json)"
+	case strings.HasSuffix(base, ".yaml") || strings.HasSuffix(base, ".yml"):
+		return "YAML: check indentation carefully after removing the conflict markers"
+	case strings.HasSuffix(base, ".md") || strings.HasSuffix(base, ".txt"):
+		return "text: usually both sides should be kept; edit the conflict markers by hand"
+	case strings.HasSuffix(base, ".go"):
+		return "edit the conflict markers, then run gofmt and go build to check the result"
+	default:
+		return "edit the conflict markers (<<<<<<< ======= >>>>>>>), or take one side with 'git checkout --ours/--theirs'"
+	}
+}
+
+// abortMerge backs out of a merge that stopped in dir, restoring the branch
+// and working tree. It does nothing if git refused to start the merge.
+func abortMerge(dir string) error {
+	if exec.Command("git", "-C", dir, "rev-parse", "-q", "--verify", "MERGE_HEAD").Run() != nil {
+		return nil
+	}
+	abortCmd := exec.Command("git", "-C", dir, "merge", "--abort")
+	if output, err := abortCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error aborting the merge: %w\n%s\nRun 'git merge --abort' in %s before merging anything else", err, string(output), dir)
+	}
+	return nil
+}
+
+// concludeConflictedMerge finishes a merge of branchName that an earlier
+// accept left in progress in gitRoot. It reports false if no merge is in
+// progress.
+func concludeConflictedMerge(gitRoot, worktreeName, branchName string) (bool, error) {
+	mergeHeadCmd := exec.Command("git", "-C", gitRoot, "rev-parse", "-q", "--verify", "MERGE_HEAD")
+	mergeHead, err := mergeHeadCmd.Output()
+	if err != nil {
+		return false, nil
+	}
+
+	branchCmd := exec.Command("git", "-C", gitRoot, "rev-parse", "refs/heads/"+branchName)
+	branchHead, err := branchCmd.Output()
+	if err != nil || strings.TrimSpace(string(branchHead)) != strings.TrimSpace(string(mergeHead)) {
+		return false, fmt.Errorf("another merge is in progress in %s; finish or abort it first", gitRoot)
+	}
+
+	if conflicts := conflictedFiles(gitRoot); len(conflicts) > 0 {
+		return false, fmt.Errorf("the merge of '%s' still has unresolved conflicts\n%s\nResolve them and 'git add' the files, then run 'autom8 accept %s' again",
+			branchName, conflictGuidance(conflicts), worktreeName)
+	}
+
+	fmt.Printf("Concluding the merge of '%s'...\n", highlightStyle.Render(branchName))
+	commitArgs := []string{"-C", gitRoot, "commit", "--no-edit"}
+	if commitMessage != "" {
+		commitArgs = []string{"-C", gitRoot, "commit", "-m", commitMessage}
+	}
+	commitCmd := exec.Command("git", commitArgs...)
+	if commitOutput, err := commitCmd.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("error committing the merge: %w\n%s", err, string(commitOutput))
+	}
+	return true, nil
+}
+
+// verifyBeforeAccept runs the task's verify command in the worktree for
+// accept --verify. A failing command stops the merge.
+func verifyBeforeAccept(worktreeName, worktreePath string) error {
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+	task := findTask(tasks, parseTaskIDFromWorktreeName(worktreeName))
+	if task == nil || task.VerifyCommand == "" {
+		fmt.Printf("%s the task has no verify command; set one with 'autom8 edit <task-id> --verify-command <cmd>'\n", subtitleStyle.Render("Note:"))
+		return nil
+	}
+
+	fmt.Printf("Running verify command: %s\n", highlightStyle.Render(task.VerifyCommand))
+	verifyCmd := hookShellCommand(context.Background(), task.VerifyCommand)
+	verifyCmd.Dir = worktreePath
+	verifyCmd.Stdout = os.Stdout
+	verifyCmd.Stderr = os.Stderr
+	if err := verifyCmd.Run(); err != nil {
+		return fmt.Errorf("verify command failed: %w\nNothing was merged; fix the worktree with 'autom8 inspect %s' and accept again", err, worktreeName)
+	}
+	fmt.Println(successStyle.Render("Verify command passed."))
+	return nil
+}
+
+// runPreAcceptHook runs hooks.pre_accept before a worktree is merged. A
+// failing hook vetoes the merge.
+func runPreAcceptHook(worktreeName, branchName string) error {
+	ev := notification{Event: "pre_accept", Task: Task{ID: parseTaskIDFromWorktreeName(worktreeName)}, Worktree: worktreeName}
+	if tasks, err := loadTasks(); err == nil {
+		for _, t := range tasks {
+			if t.ID == ev.Task.ID {
+				ev.Task = t
+				break
+			}
+		}
+	}
+	if err := runHook(ev, branchName); err != nil {
+		return fmt.Errorf("merge vetoed: %w", err)
+	}
+	return nil
+}
+
+// pushAfterAccept pushes the branch that was merged into when --push or
+// accept.push is set. The merge has already succeeded, so failures only warn.
+func pushAfterAccept(gitRoot, branch string) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Printf("%s could not load config: %v\n", errorStyle.Render("Warning:"), err)
+	}
+	if !pushFlag && !cfg.Accept.Push {
+		return
+	}
+
+	remote := remoteFlag
+	if remote == "" {
+		remote = cfg.Accept.Remote
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+
+	if branch == "" {
+		fmt.Printf("%s could not determine the branch to push, skipping push\n", errorStyle.Render("Warning:"))
+		return
+	}
+
+	fmt.Printf("Pushing '%s' to %s...\n", highlightStyle.Render(branch), remote)
+	pushCmd := exec.Command("git", "-C", gitRoot, "push", remote, branch)
+	if pushOutput, err := pushCmd.CombinedOutput(); err != nil {
+		fmt.Printf("%s push failed: %v\n%s", errorStyle.Render("Warning:"), err, string(pushOutput))
+		fmt.Printf("The merge is kept locally. Push it manually with: git push %s %s\n", remote, branch)
+		return
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("Pushed '%s' to %s.", branch, remote)))
+}
+
+// openPullRequest pushes a worktree branch to origin and opens a pull/merge
+// request against baseBranch. The worktree and task are left as-is.
+func openPullRequest(gitRoot, worktreeName, worktreePath, branchName, baseBranch string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	forge, err := detectForge(gitRoot, cfg)
+	if err != nil {
+		return err
+	}
+
+	if baseBranch == "" {
+		return fmt.Errorf("could not determine the current branch to target; pass --into")
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	taskID := parseTaskIDFromWorktreeName(worktreeName)
+	var task *Task
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			task = &tasks[i]
+			break
+		}
+	}
+	if task == nil {
+		return ErrTaskNotFound{ID: taskID}
+	}
+
+	fmt.Printf("Pushing branch '%s' to origin...\n", highlightStyle.Render(branchName))
+	pushCmd := exec.Command("git", "-C", worktreePath, "push", "-u", "origin", branchName)
+	if pushOutput, err := pushCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error pushing branch: %w\n%s", err, string(pushOutput))
+	}
+
+	statCmd := exec.Command("git", "-C", worktreePath, "diff", baseBranch+"...HEAD", "--stat")
+	statOutput, _ := statCmd.Output()
+
+	title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(task.Prompt), "\n", 2)[0])
+	body := buildPRBody(task, string(statOutput), baseBranch)
+	if ref := task.ExternalRef; ref != nil && ref.Provider == forge.Name() && strings.EqualFold(ref.Repo, forge.Repo()) {
+		body += fmt.Sprintf("\nCloses #%d\n", ref.Number)
+	}
+
+	fmt.Printf("Opening %s pull request against '%s'...\n", forgeDisplayName(forge.Name()), highlightStyle.Render(baseBranch))
+	prURL, err := forge.CreatePullRequest(branchName, baseBranch, title, body)
+	if err != nil {
+		return fmt.Errorf("error creating pull request: %w", err)
+	}
+
+	issues := newIssueSync(gitRoot)
+	issues.add(*task, fmt.Sprintf("Opened %s for `%s` (autom8 accept --pr).", prURL, branchName))
+	issues.flush()
+
+	fmt.Println()
+	fmt.Println(successStyle.Render(fmt.Sprintf("Opened %s", prURL)))
+	fmt.Println(subtitleStyle.Render(fmt.Sprintf("The worktree is kept for follow-up changes. Once merged, run 'autom8 status set %s completed'.", taskID)))
+	return nil
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("task ID required\nRun 'autom8 list' to see task IDs")
+	}
+
+	taskID := args[0]
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	// Find the task
+	taskIndex := -1
+	for i, t := range tasks {
+		if t.ID == taskID {
+			taskIndex = i
+			break
+		}
+	}
+
+	if taskIndex == -1 {
+		return ErrTaskNotFound{ID: taskID}
+	}
+
+	autom8Path, _ := getAutom8Dir()
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+
+	if cascadeFlag {
+		return deleteCascade(tasks, taskID, gitRoot, autom8Path)
+	}
+
+	// Check if any other tasks depend on this one
+	var dependents []string
+	for _, t := range tasks {
+		if t.DependsOn == taskID {
+			dependents = append(dependents, t.ID)
+		}
+	}
+
+	if len(dependents) > 0 {
+		return ErrHasDependents{TaskID: taskID, Dependents: dependents}
+	}
+
+	deleted := map[string]bool{taskID: true}
+	plan := planTaskDeletion(gitRoot, autom8Path, deleted)
+	worktreesRemoved, left := plan.apply(gitRoot, worktreesDir, killFlag)
+
+	if err := removeTasks(deleted); err != nil {
+		return err
+	}
+
+	if worktreesRemoved > 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("Task '%s' deleted, removed %d worktree(s).", taskID, worktreesRemoved)))
+	} else {
+		fmt.Println(successStyle.Render(fmt.Sprintf("Task '%s' deleted.", taskID)))
+	}
+	warnLeftWorktrees(left, plan)
+	return removeTaskLogs(autom8Path, plan.logDirs(left), taskID)
+}
+
+// deleteCascade deletes a task and all of its transitive dependents after
+// listing what will be destroyed and confirming.
+func deleteCascade(tasks []Task, taskID, gitRoot, autom8Path string) error {
+	order := cascadeOrder(tasks, taskID)
+	deleted := make(map[string]bool, len(order))
+	for _, id := range order {
+		deleted[id] = true
+	}
+	plan := planTaskDeletion(gitRoot, autom8Path, deleted)
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Deleting %d task(s)", len(order))))
+	for _, id := range order {
+		fmt.Printf("  %s\n", idStyle.Render(id))
+	}
+	plan.print()
+	fmt.Println()
+
+	if !yesFlag {
+		if !isTerminal(os.Stdin) {
+			return fmt.Errorf("--cascade needs confirmation; run it from an interactive terminal or pass --yes")
+		}
+
+		var confirmed bool
+		err := huh.NewConfirm().
+			Title(fmt.Sprintf("Delete these %d task(s) and their worktrees?", len(order))).
+			Affirmative("Delete").
+			Negative("Cancel").
+			Value(&confirmed).
+			WithTheme(huh.ThemeDracula()).
+			Run()
+		if err != nil && err != huh.ErrUserAborted {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	worktreesRemoved, left := plan.apply(gitRoot, filepath.Join(autom8Path, "worktrees"), killFlag)
+
+	if err := removeTasks(deleted); err != nil {
+		return err
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		fmt.Printf("  %s %s\n", successStyle.Render("[deleted]"), order[i])
+	}
+
+	fmt.Println()
+	fmt.Println(successStyle.Render(fmt.Sprintf("Deleted %d task(s), removed %d worktree(s).", len(order), worktreesRemoved)))
+	warnLeftWorktrees(left, plan)
+	return removeTaskLogs(autom8Path, plan.logDirs(left), taskID)
+}
+
+// cascadeOrder returns taskID followed by its transitive dependents, each
+// after the task it depends on (a BFS from taskID). A hand-edited tasks.json
+// may contain a cycle, so each task is visited once.
+func cascadeOrder(tasks []Task, taskID string) []string {
+	order := []string{taskID}
+	seen := map[string]bool{taskID: true}
+	for i := 0; i < len(order); i++ {
+		for _, t := range tasks {
+			if t.DependsOn == order[i] && !seen[t.ID] {
+				seen[t.ID] = true
+				order = append(order, t.ID)
+			}
+		}
+	}
+	return order
+}
+
+// removeTasks drops the given tasks from tasks.json under its lock, so
+// updates made meanwhile (an agent stopped by --kill on its way out, a watch
+// claiming a task) are kept.
+func removeTasks(ids map[string]bool) error {
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		remaining := make([]Task, 0, len(tasks))
+		for _, t := range tasks {
+			if !ids[t.ID] {
+				remaining = append(remaining, t)
+			}
+		}
+		return remaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error saving tasks: %w", err)
+	}
+	return nil
+}
+
+// taskDeletion is everything that deleting a set of tasks destroys besides
+// the tasks themselves.
+type taskDeletion struct {
+	Worktrees []string         // Names of the tasks' worktrees
+	Branches  []string         // The tasks' autom8/ branches, with or without a worktree
+	Running   map[string]int   // PID of the agent running in a worktree, by worktree name
+	Others    map[int][]string // Worktrees of other tasks run by the same PIDs
+	LogDirs   []string
+}
+
+// planTaskDeletion collects the worktrees, branches, running agents and log
+// directories that belong to the given tasks.
+func planTaskDeletion(gitRoot, autom8Path string, taskIDs map[string]bool) taskDeletion {
+	d := taskDeletion{Running: make(map[string]int), Others: make(map[int][]string)}
+
+	names, _ := listWorktreeNames(filepath.Join(autom8Path, "worktrees"))
+	for _, name := range names {
+		if taskIDs[parseTaskIDFromWorktreeName(name)] {
+			d.Worktrees = append(d.Worktrees, name)
+		}
+	}
+
+	out, _ := exec.Command("git", "-C", gitRoot, "for-each-ref", "--format=%(refname:short)", "refs/heads/autom8/").Output()
+	for _, branch := range strings.Fields(string(out)) {
+		if taskIDs[parseTaskIDFromWorktreeName(strings.TrimPrefix(branch, "autom8/"))] {
+			d.Branches = append(d.Branches, branch)
+		}
+	}
+
+	// One autom8 process runs every worktree of an implement run, so note
+	// the other worktrees that stopping it would also stop
+	pids, _ := loadPids()
+	stopping := make(map[int]bool)
+	for name, pid := range pids {
+		if taskIDs[parseTaskIDFromWorktreeName(name)] && isProcessRunning(pid) {
+			d.Running[name] = pid
+			stopping[pid] = true
+		}
+	}
+	for name, pid := range pids {
+		if stopping[pid] && !taskIDs[parseTaskIDFromWorktreeName(name)] {
+			d.Others[pid] = append(d.Others[pid], name)
+		}
+	}
+
+	d.LogDirs = taskLogDirs(filepath.Join(autom8Path, "logs"), taskIDs)
+	return d
+}
+
+// print lists the plan under the task list of 'delete --cascade'.
+func (d taskDeletion) print() {
+	// Without --kill, running agents keep their worktree, branch and logs
+	var skipped []string
+	skippedBranches := make(map[string]bool)
+	if !killFlag {
+		for name := range d.Running {
+			skipped = append(skipped, name)
+			skippedBranches["autom8/"+name] = true
+		}
+	}
+
+	if len(d.Worktrees) > 0 {
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Worktrees (%d):", len(d.Worktrees))))
+		for _, name := range d.Worktrees {
+			pid, running := d.Running[name]
+			switch {
+			case !running:
+				fmt.Printf("  %s\n", name)
+			case killFlag:
+				fmt.Printf("  %s %s\n", name, errorStyle.Render(fmt.Sprintf("(agent running, pid %d, will be stopped)", pid)))
+			default:
+				fmt.Printf("  %s %s\n", name, subtitleStyle.Render(fmt.Sprintf("(agent running, pid %d, will be skipped; pass --kill to stop it)", pid)))
+			}
+		}
+	}
+	var branches []string
+	for _, branch := range d.Branches {
+		if !skippedBranches[branch] {
+			branches = append(branches, branch)
+		}
+	}
+	if len(branches) > 0 {
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Branches (%d):", len(branches))))
+		for _, branch := range branches {
+			fmt.Printf("  %s\n", branch)
+		}
+	}
+	if logDirs := d.logDirs(skipped); len(logDirs) > 0 {
+		fmt.Println()
+		action := "Logs"
+		if keepLogsFlag {
+			action = "Logs, archived first"
+		}
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("%s (%d):", action, len(logDirs))))
+		for _, name := range logDirs {
+			fmt.Printf("  .autom8/logs/%s\n", name)
+		}
+	}
+	if killFlag {
+		for pid, others := range d.Others {
+			fmt.Println()
+			fmt.Printf("%s pid %d also runs %s, which will stop too\n", errorStyle.Render("Warning:"), pid, strings.Join(others, ", "))
+		}
+	}
+}
+
+// apply removes the planned worktrees and branches, stopping running agents
+// first when kill is set, and forgets their PIDs and stats. It returns how
+// many worktrees were removed and the names of those left behind.
+func (d taskDeletion) apply(gitRoot, worktreesDir string, kill bool) (int, []string) {
+	var left []string
+	if kill && len(d.Running) > 0 {
+		stopped := make(map[int]bool)
+		for _, pid := range d.Running {
+			if !stopped[pid] {
+				stopped[pid] = true
+				if err := stopProcess(pid); err != nil {
+					fmt.Printf("%s could not stop pid %d: %v\n", errorStyle.Render("Warning:"), pid, err)
+				}
+			}
+		}
+		// Give the agents a moment to shut down and clean up
+		deadline := time.Now().Add(10 * time.Second)
+		for pid := range stopped {
+			for isProcessRunning(pid) && time.Now().Before(deadline) {
+				time.Sleep(200 * time.Millisecond)
+			}
+		}
+	}
+
+	var removed []string
+	for _, name := range d.Worktrees {
+		if pid, ok := d.Running[name]; ok && (!kill || isProcessRunning(pid)) {
+			left = append(left, name)
+			continue
+		}
+		if removeWorktreeAndBranch(gitRoot, filepath.Join(worktreesDir, name)) {
+			removed = append(removed, name)
+		} else {
+			left = append(left, name)
+		}
+	}
+
+	// Branches whose worktree was already gone; a branch still checked out
+	// in a worktree left behind is refused by git and kept
+	for _, branch := range d.Branches {
+		exec.Command("git", "-C", gitRoot, "branch", "-D", branch).Run()
+	}
+
+	forgetWorktrees(removed)
+	return len(removed), left
+}
+
+// logDirs returns the planned log directories, except those of worktrees
+// left behind, whose agents may still be writing to them.
+func (d taskDeletion) logDirs(left []string) []string {
+	keep := make(map[string]bool)
+	for _, name := range left {
+		keep[name] = true
+	}
+	var names []string
+	for _, name := range d.LogDirs {
+		if !keep[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// forgetWorktrees drops the PID and stats entries of removed worktrees.
+func forgetWorktrees(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	pidsMu.Lock()
+	if pids, err := loadPids(); err == nil {
+		for _, name := range names {
+			delete(pids, name)
+		}
+		savePids(pids)
+	}
+	pidsMu.Unlock()
+
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	if stats, err := loadWorktreeStats(); err == nil {
+		for _, name := range names {
+			delete(stats, name)
+		}
+		saveWorktreeStats(stats)
+	}
+}
+
+// warnLeftWorktrees reports worktrees that outlive their deleted task.
+func warnLeftWorktrees(left []string, d taskDeletion) {
+	if len(left) == 0 {
+		return
+	}
+	fmt.Printf("%s %d worktree(s) were left behind without a task:\n", errorStyle.Render("Warning:"), len(left))
+	for _, name := range left {
+		if pid, ok := d.Running[name]; ok {
+			fmt.Printf("  %s (agent running, pid %d)\n", name, pid)
+		} else {
+			fmt.Printf("  %s (could not be removed)\n", name)
+		}
+	}
+	if len(d.Running) > 0 && !killFlag {
+		fmt.Println(subtitleStyle.Render("Remove them with 'git worktree remove' once their agents finish, or pass --kill next time to stop the agents first."))
+	}
+}
+
+// removeWorktreeAndBranch force-removes a worktree and deletes its branch,
+// and reports whether the worktree was removed.
+func removeWorktreeAndBranch(gitRoot, worktreePath string) bool {
+	// Get branch name before removing
+	branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
+	branchOutput, _ := branchCmd.Output()
+	branchName := strings.TrimSpace(string(branchOutput))
+
+	// Remove worktree
+	removeCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", "--force", worktreePath)
+	if removeCmd.Run() != nil {
+		return false
+	}
+	// Delete the branch
+	if branchName != "" {
+		deleteBranchCmd := exec.Command("git", "-C", gitRoot, "branch", "-D", branchName)
+		deleteBranchCmd.Run()
+	}
+	return true
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	autom8Path, _ := getAutom8Dir()
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+
+	prunedIDs := make(map[string]bool)
+	for _, t := range tasks {
+		if t.Status == "completed" {
+			prunedIDs[t.ID] = true
+		}
+	}
+
+	if len(prunedIDs) == 0 {
+		fmt.Println(subtitleStyle.Render("No completed tasks to prune."))
+		return nil
+	}
+
+	plan := planTaskDeletion(gitRoot, autom8Path, prunedIDs)
+	base := defaultBranch(gitRoot)
+
+	if dryRunFlag {
+		printPrunePlan(gitRoot, base, prunedIDs, plan)
+		return nil
+	}
+
+	// Remove the worktrees; a task keeps its entry while any of them is left
+	var removed, failed []string
+	keptIDs := make(map[string]bool)
+	for _, name := range plan.Worktrees {
+		if pid, ok := plan.Running[name]; ok {
+			fmt.Printf("  %s %s: agent running (pid %d)\n", errorStyle.Render("[failed]"), name, pid)
+		} else if output, err := removeWorktree(gitRoot, filepath.Join(worktreesDir, name)); err != nil {
+			fmt.Printf("  %s %s: %s\n", errorStyle.Render("[failed]"), name, output)
+		} else {
+			fmt.Printf("  %s %s\n", successStyle.Render("[removed]"), name)
+			removed = append(removed, name)
+			continue
+		}
+		failed = append(failed, name)
+		keptIDs[parseTaskIDFromWorktreeName(name)] = true
+	}
+	forgetWorktrees(removed)
+
+	// Branches, including those whose worktree was already gone
+	var branchesDeleted, branchesKept int
+	for _, branch := range plan.Branches {
+		if keptIDs[parseTaskIDFromWorktreeName(strings.TrimPrefix(branch, "autom8/"))] {
+			continue
+		}
+		if reason := pruneBranch(gitRoot, branch, base); reason != "" {
+			fmt.Printf("  %s %s (%s)\n", subtitleStyle.Render("[kept]"), branch, reason)
+			branchesKept++
+		} else {
+			branchesDeleted++
+		}
+	}
+
+	// Drop the registrations of worktrees whose directories are gone
+	if output, err := exec.Command("git", "-C", gitRoot, "worktree", "prune").CombinedOutput(); err != nil {
+		fmt.Printf("%s git worktree prune failed: %v\n%s", errorStyle.Render("Warning:"), err, string(output))
+	}
+
+	// tasks.json is reread under its lock: the work above takes a while,
+	// and tasks may have been added or reopened meanwhile
+	var pruned int
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		remaining := make([]Task, 0, len(tasks))
+		for _, t := range tasks {
+			if prunedIDs[t.ID] && !keptIDs[t.ID] && t.Status == "completed" {
+				pruned++
+			} else {
+				remaining = append(remaining, t)
+			}
+		}
+		return remaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error saving tasks: %w", err)
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("Pruned %d completed task(s), removed %d worktree(s) and %d branch(es).", pruned, len(removed), branchesDeleted)))
+	if len(failed) > 0 {
+		fmt.Printf("%s %d worktree(s) could not be removed; their %d task(s) were kept so 'autom8 prune' can retry (--force removes worktrees with local changes)\n",
+			errorStyle.Render("Warning:"), len(failed), len(keptIDs))
+	}
+	if branchesKept > 0 {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Kept %d unmerged branch(es); delete them with 'git branch -D', or pass --force next time.", branchesKept)))
+	}
+	return removeTaskLogs(autom8Path, plan.logDirs(failed), "prune")
+}
+
+// printPrunePlan lists what prune would remove, for --dry-run.
+func printPrunePlan(gitRoot, base string, prunedIDs map[string]bool, plan taskDeletion) {
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Would prune %d completed task(s)", len(prunedIDs))))
+	var ids []string
+	for id := range prunedIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Printf("  %s\n", idStyle.Render(id))
+	}
+	if len(plan.Worktrees) > 0 {
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Worktrees (%d):", len(plan.Worktrees))))
+		for _, name := range plan.Worktrees {
+			if pid, ok := plan.Running[name]; ok {
+				fmt.Printf("  %s %s\n", name, subtitleStyle.Render(fmt.Sprintf("(agent running, pid %d, would be skipped)", pid)))
+			} else {
+				fmt.Printf("  %s\n", name)
+			}
+		}
+	}
+	if len(plan.Branches) > 0 {
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Branches (%d):", len(plan.Branches))))
+		for _, branch := range plan.Branches {
+			switch {
+			case branchMerged(gitRoot, branch, "HEAD"):
+				fmt.Printf("  %s %s\n", branch, subtitleStyle.Render("(merged)"))
+			case branchMerged(gitRoot, branch, base):
+				// git branch -d only looks at HEAD; no work is lost since base has it all
+				fmt.Printf("  %s %s\n", branch, subtitleStyle.Render(fmt.Sprintf("(merged into %s, deleted with -D even without --force)", base)))
+			case forceFlag:
+				fmt.Printf("  %s %s\n", branch, errorStyle.Render(fmt.Sprintf("(not merged into %s, deleted by --force)", base)))
+			default:
+				fmt.Printf("  %s %s\n", branch, subtitleStyle.Render(fmt.Sprintf("(not merged into %s, would be kept; --force deletes it)", base)))
+			}
+		}
+	}
+	if len(plan.LogDirs) > 0 {
+		fmt.Println()
+		action := "Logs"
+		if keepLogsFlag {
+			action = "Logs, archived first"
+		}
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("%s (%d):", action, len(plan.LogDirs))))
+		for _, name := range plan.LogDirs {
+			fmt.Printf("  .autom8/logs/%s\n", name)
+		}
+	}
+}
+
+// removeWorktree removes a worktree, refusing one with local changes unless
+// --force. It returns git's output on failure.
+func removeWorktree(gitRoot, worktreePath string) (string, error) {
+	args := []string{"-C", gitRoot, "worktree", "remove", worktreePath}
+	if forceFlag {
+		args = []string{"-C", gitRoot, "worktree", "remove", "--force", worktreePath}
+	}
+	output, err := exec.Command("git", args...).CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// pruneBranch deletes a branch with 'git branch -d', which refuses unmerged
+// work. A branch merged into the default branch, rather than the current
+// one, or any branch with --force is then deleted with -D. It returns why
+// the branch was kept, or "" once deleted.
+func pruneBranch(gitRoot, branch, base string) string {
+	if exec.Command("git", "-C", gitRoot, "branch", "-d", branch).Run() == nil {
+		return ""
+	}
+	if !forceFlag && !branchMerged(gitRoot, branch, base) {
+		return fmt.Sprintf("not merged into %s", base)
+	}
+	if output, err := exec.Command("git", "-C", gitRoot, "branch", "-D", branch).CombinedOutput(); err != nil {
+		return strings.TrimSpace(string(output))
+	}
+	return ""
+}
+
+// branchMerged reports whether every commit of branch is reachable from base.
+func branchMerged(gitRoot, branch, base string) bool {
+	return exec.Command("git", "-C", gitRoot, "merge-base", "--is-ancestor", branch, base).Run() == nil
+}
+
+// defaultBranch returns the repository's default branch: the one origin's
+// HEAD points at, else main or master, whichever exists.
+func defaultBranch(gitRoot string) string {
+	if output, err := exec.Command("git", "-C", gitRoot, "symbolic-ref", "--short", "refs/remotes/origin/HEAD").Output(); err == nil {
+		return strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/")
+	}
+	for _, name := range []string{"main", "master"} {
+		if exec.Command("git", "-C", gitRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+name).Run() == nil {
+			return name
+		}
+	}
+	return "main"
+}
+
+// removeTaskLogs deletes the named log directories of removed tasks, or
+// with --keep-logs archives them under archiveName first.
+func removeTaskLogs(autom8Path string, names []string, archiveName string) error {
+	logsRoot := filepath.Join(autom8Path, "logs")
+	if !keepLogsFlag {
+		archiveName = ""
+	}
+	archivePath, err := removeLogDirs(logsRoot, names, archiveName)
+	if err != nil {
+		return fmt.Errorf("%w\nThe logs were left in %s", err, logsRoot)
+	}
+	if archivePath != "" {
+		fmt.Printf("  %s %d worktree log dir(s) to %s\n", subtitleStyle.Render("Archived logs:"), len(names), archivePath)
+	} else if len(names) > 0 {
+		fmt.Printf("  %s %d worktree log dir(s)\n", subtitleStyle.Render("Removed logs:"), len(names))
+	}
+	return nil
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+
+	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+
+	// Check if worktree exists
+	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+	touchWorktree(worktreeName)
+
+	if inspectCommand != "" || len(args) > 1 {
+		var runCmd *exec.Cmd
+		if len(args) > 1 {
+			runCmd = exec.Command(args[1], args[2:]...)
+		} else {
+			runCmd = hookShellCommand(context.Background(), inspectCommand)
+		}
+		runCmd.Dir = worktreePath
+		runCmd.Stdin = os.Stdin
+		runCmd.Stdout = os.Stdout
+		runCmd.Stderr = os.Stderr
+		runCmd.Env = append(os.Environ(), fmt.Sprintf("AUTOM8_WORKTREE=%s", worktreeName))
+		if err := runCmd.Run(); err != nil {
+			// Hand the command's exit code to the caller, as if it ran directly
+			if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() > 0 {
+				os.Exit(exitErr.ExitCode())
+			}
+			return fmt.Errorf("command failed in %s: %w", worktreeName, err)
+		}
+		return nil
+	}
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return fmt.Errorf("no terminal for an interactive shell\nRun a single command with: autom8 inspect %s -- <command>", worktreeName)
+	}
+
+	if !inspectQuiet {
+		// Get worktree info for display
+		worktreesDir := filepath.Join(autom8Path, "worktrees")
+		pids, _ := loadPids()
+		info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+
+		fmt.Println(titleStyle.Render("Inspecting Worktree"))
+		fmt.Println()
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Worktree:"), highlightStyle.Render(worktreeName))
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(info.Branch))
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Path:"), worktreePath)
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render("Starting a new shell in the worktree directory..."))
+		fmt.Println(subtitleStyle.Render("Type 'exit' or press Ctrl+D to return."))
+		fmt.Println()
+	}
+
+	// Start an interactive shell in the worktree directory
+	shellCmd := interactiveShellCommand()
+	shellCmd.Dir = worktreePath
+	shellCmd.Stdin = os.Stdin
+	shellCmd.Stdout = os.Stdout
+	shellCmd.Stderr = os.Stderr
+
+	// Set a custom prompt to remind the user they're in an autom8 worktree
+	env := os.Environ()
+	env = append(env, fmt.Sprintf("AUTOM8_WORKTREE=%s", worktreeName))
+	shellCmd.Env = env
+
+	if err := shellCmd.Run(); err != nil {
+		// Exit code from shell is not an error for us
+		if _, ok := err.(*exec.ExitError); !ok {
+			return fmt.Errorf("error running shell: %w", err)
+		}
+	}
+
+	if !inspectQuiet {
+		fmt.Println()
+		fmt.Println(successStyle.Render("Exited worktree inspection."))
+	}
+	return nil
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+	if diffContext < 0 {
+		return fmt.Errorf("--context must not be negative")
+	}
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+
+	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+
+	// Check if worktree exists
+	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+	touchWorktree(worktreeName)
+
+	if prBodyFlag {
+		showFormat = "github"
+	}
+	switch showFormat {
+	case "pretty":
+	case "github":
+		return showPRBody(worktreeName, worktreePath)
+	default:
+		return fmt.Errorf("unknown format '%s' (expected pretty or github)", showFormat)
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+	base := defaultBranch(gitRoot)
+
+	// Get worktree info for display
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	pids, _ := loadPids()
+	info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+
+	// Print header info directly to stdout
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Diff: %s...%s", base, info.Branch)))
+	fmt.Println()
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Worktree:"), highlightStyle.Render(worktreeName))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(info.Branch))
+	fmt.Printf("  %s %s commit(s) ahead of %s\n", subtitleStyle.Render("Commits:"), info.CommitsAhead, base)
+	fmt.Println()
+
+	// Get the diff between the default branch and the worktree branch
+	diffCmd := exec.Command("git", "-C", worktreePath, "diff", base+"...HEAD", "--stat")
+	statOutput, _ := diffCmd.Output()
+
+	if len(statOutput) > 0 {
+		fmt.Println(subtitleStyle.Render("Files changed:"))
+		fmt.Println(string(statOutput))
+	}
+
+	// Get the full diff
+	fullDiffCmd := exec.Command("git", "-C", worktreePath, "diff", fmt.Sprintf("-U%d", diffContext), base+"...HEAD")
+	fullDiffOutput, err := fullDiffCmd.Output()
+	if err != nil {
+		return fmt.Errorf("error getting diff: %w", err)
+	}
+
+	if len(fullDiffOutput) == 0 {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("No changes from %s.", base)))
+		return nil
+	}
+
+	fmt.Println(subtitleStyle.Render("Diff:"))
+	fmt.Println()
+
+	// Pipe the full diff through less for scrollable viewing
+	// Fall back to direct print if less is unavailable
+	if err := pipeToLess(fullDiffOutput); err != nil {
+		// Fallback: print directly to stdout
+		fmt.Println(string(fullDiffOutput))
+	}
+
+	if copyFlag {
+		if err := copyToClipboard(fullDiffOutput); err != nil {
+			return fmt.Errorf("error copying to clipboard: %w", err)
+		}
+		// Report on stderr so piped stdout only contains the diff
+		fmt.Fprintln(os.Stderr, subtitleStyle.Render("(copied to clipboard)"))
+	}
+
+	return nil
+}
+
+// showPRBody prints a markdown PR description for the worktree's task.
+func showPRBody(worktreeName, worktreePath string) error {
+	taskID := parseTaskIDFromWorktreeName(worktreeName)
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	var task *Task
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			task = &tasks[i]
+			break
+		}
+	}
+
+	if task == nil {
+		return ErrTaskNotFound{ID: taskID}
+	}
+
+	// The PR targets the repository's default branch, which need not be main
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+	base := defaultBranch(gitRoot)
+	statCmd := exec.Command("git", "-C", worktreePath, "diff", base+"...HEAD", "--stat")
+	statOutput, err := statCmd.Output()
+	if err != nil {
+		return fmt.Errorf("error getting diff stat: %w", err)
+	}
+
+	body := buildPRBody(task, string(statOutput), base)
+	fmt.Print(body)
+
+	if copyFlag {
+		if err := copyToClipboard([]byte(body)); err != nil {
+			return fmt.Errorf("error copying to clipboard: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, subtitleStyle.Render("(copied to clipboard)"))
+	}
+
+	return nil
+}
+
+func buildPRBody(task *Task, diffStat, base string) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString(strings.TrimSpace(task.Prompt))
+	sb.WriteString("\n\n")
+
+	if len(task.VerificationCriteria) > 0 {
+		sb.WriteString("## Verification\n\n")
+		for _, c := range task.VerificationCriteria {
+			sb.WriteString(fmt.Sprintf("- [ ] %s\n", c))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Changes\n\n")
+	if strings.TrimSpace(diffStat) == "" {
+		sb.WriteString(fmt.Sprintf("No changes from %s.\n", base))
+	} else {
+		sb.WriteString("```\n")
+		sb.WriteString(diffStat)
+		sb.WriteString("```\n")
+	}
+
+	return sb.String()
+}
+
+// copyToClipboard writes content to the system clipboard using the platform's
+// clipboard helper (pbcopy on macOS, xclip on Linux, Set-Clipboard on Windows).
+func copyToClipboard(content []byte) error {
+	var clipCmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		clipCmd = exec.Command("pbcopy")
+	case "windows":
+		clipCmd = exec.Command("powershell", "-NoProfile", "-Command", "Set-Clipboard -Value ([Console]::In.ReadToEnd())")
+	default:
+		clipCmd = exec.Command("xclip", "-selection", "clipboard")
+	}
+
+	// Err is set when the helper binary could not be found in PATH
+	if clipCmd.Err != nil {
+		return clipCmd.Err
+	}
+
+	clipCmd.Stdin = bytes.NewReader(content)
+	if output, err := clipCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w\n%s", err, string(output))
+	}
+	return nil
+}
+
+// pipeToLess pipes the given content through the less pager for scrollable viewing.
+// Returns an error if less is unavailable or fails to run.
+func pipeToLess(content []byte) error {
+	// Check if less is available
+	lessPath, err := exec.LookPath("less")
+	if err != nil {
+		return fmt.Errorf("less not found: %w", err)
+	}
+
+	// Create the less command with options for color support
+	lessCmd := exec.Command(lessPath, "-R")
+	lessCmd.Stdout = os.Stdout
+	lessCmd.Stderr = os.Stderr
+
+	// Get stdin pipe to write content
+	stdin, err := lessCmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+
+	// Start less
+	if err := lessCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start less: %w", err)
+	}
+
+	// Write content to less stdin
+	stdin.Write(content)
+	stdin.Close()
+
+	// Wait for less to finish (user quits with 'q')
+	if err := lessCmd.Wait(); err != nil {
+		// Ignore exit errors from less (e.g., user pressing 'q')
+		if _, ok := err.(*exec.ExitError); !ok {
+			return fmt.Errorf("less failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func runChat(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+
+	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+
+	// Check if worktree exists
+	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	taskID := parseTaskIDFromWorktreeName(worktreeName)
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	mcpConfig, err := loadMCPConfig(gitRoot)
+	if err != nil {
+		return err
+	}
+
+	// Load task details
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	var task *Task
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			task = &tasks[i]
+			break
+		}
+	}
+
+	if task == nil {
+		return ErrTaskNotFound{ID: taskID}
+	}
+
+	// Get worktree info for display
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	pids, _ := loadPids()
+	info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+
+	// Gather git log since branching from the default branch
+	base := defaultBranch(gitRoot)
+	logCmd := exec.Command("git", "-C", worktreePath, "log", "--oneline", base+"..HEAD")
+	logOutput, _ := logCmd.Output()
+
+	// Gather diff from the default branch
+	diffCmd := exec.Command("git", "-C", worktreePath, "diff", base+"...HEAD")
+	diffOutput, _ := diffCmd.Output()
+
+	// Build system prompt with context
+	systemPrompt := buildChatSystemPrompt(task, worktreeName, info.Branch, base, string(logOutput), string(diffOutput))
+
+	// Display worktree info before starting
+	fmt.Println(titleStyle.Render("Interactive Chat Session"))
+	fmt.Println()
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Worktree:"), highlightStyle.Render(worktreeName))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(info.Branch))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Task ID:"), idStyle.Render(taskID))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Task:"), truncate(task.Prompt, 60))
+	if info.CommitsAhead != "0" {
+		fmt.Printf("  %s %s commit(s) ahead of %s\n", subtitleStyle.Render("Progress:"), info.CommitsAhead, base)
+	}
+	fmt.Println()
+	fmt.Println(subtitleStyle.Render("Starting interactive Claude session with task context..."))
+	fmt.Println(subtitleStyle.Render("Type your questions or instructions. Use Ctrl+C to exit."))
+	fmt.Println()
+
+	// Launch interactive Claude session with system prompt
+	claudeArgs := withMCPConfig([]string{"--dangerously-skip-permissions", "--system-prompt", systemPrompt}, mcpConfig)
+	claudeCmd := exec.Command("claude", claudeArgs...)
+	claudeCmd.Dir = worktreePath
+	claudeCmd.Stdin = os.Stdin
+	claudeCmd.Stdout = os.Stdout
+	claudeCmd.Stderr = os.Stderr
+
+	if err := claudeCmd.Run(); err != nil {
+		// Exit code from claude is not necessarily an error for us
+		if _, ok := err.(*exec.ExitError); !ok {
+			return fmt.Errorf("error running claude: %w", err)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(successStyle.Render("Chat session ended."))
+	return nil
+}
+
+func buildChatSystemPrompt(task *Task, worktreeName, branchName, base, gitLog, gitDiff string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Context for This Worktree\n\n")
+	sb.WriteString("You are assisting with an implementation task in a git worktree. ")
+	sb.WriteString("The user wants to either ask questions about the implementation or give you instructions to continue/fix it.\n\n")
+
+	sb.WriteString("## Original Task\n\n")
+	sb.WriteString(task.Prompt)
+	sb.WriteString("\n\n")
+
+	if len(task.VerificationCriteria) > 0 {
+		sb.WriteString("## Verification Criteria\n\n")
+		sb.WriteString("The implementation should satisfy these criteria:\n")
+		for _, c := range task.VerificationCriteria {
+			sb.WriteString(fmt.Sprintf("- %s\n", c))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Current State\n\n")
+	sb.WriteString(fmt.Sprintf("- **Worktree:** %s\n", worktreeName))
+	sb.WriteString(fmt.Sprintf("- **Branch:** %s\n", branchName))
+	sb.WriteString(fmt.Sprintf("- **Task ID:** %s\n\n", task.ID))
+
+	if gitLog != "" {
+		sb.WriteString(fmt.Sprintf("## Commits Since %s\n\n", base))
+		sb.WriteString("These commits have been made in this worktree:\n\n")
+		sb.WriteString("```\n")
+		sb.WriteString(gitLog)
+		sb.WriteString("```\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("## Commits Since %s\n\n", base))
+		sb.WriteString("No commits have been made yet in this worktree.\n\n")
+	}
+
+	if gitDiff != "" {
+		// Truncate very large diffs to avoid overwhelming the context
+		diff := gitDiff
+		if len(diff) > 50000 {
+			diff = diff[:50000] + "\n... (diff truncated due to size)"
+		}
+		sb.WriteString(fmt.Sprintf("## Current Diff from %s\n\n", base))
+		sb.WriteString("```diff\n")
+		sb.WriteString(diff)
+		sb.WriteString("```\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("## Current Diff from %s\n\n", base))
+		sb.WriteString(fmt.Sprintf("No changes from %s yet.\n\n", base))
+	}
+
+	sb.WriteString("## Your Role\n\n")
+	sb.WriteString("Help the user with this implementation. They may:\n")
+	sb.WriteString("- Ask questions about what has been implemented\n")
+	sb.WriteString("- Request explanations of the code changes\n")
+	sb.WriteString("- Give instructions to continue or fix the implementation\n")
+	sb.WriteString("- Ask you to make specific changes\n\n")
+	sb.WriteString("You have full access to the codebase in this worktree. Feel free to read files, make edits, and run commands as needed.\n")
+
+	return sb.String()
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	var since time.Time
+	if sinceFlag != "" {
+		since, err = parseSince(sinceFlag, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	autom8Path, _ := getAutom8Dir()
+	report := buildReport(tasks, since, gitRoot, filepath.Join(autom8Path, "logs"))
+
+	if outFlag == "" {
+		fmt.Print(report)
+		return nil
+	}
+	if err := os.WriteFile(outFlag, []byte(report), 0644); err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("Report written to %s", outFlag)))
+	return nil
+}
+
+// parseSince accepts a number of days (14d) or weeks (2w), a Go duration
+// (36h) or a date (2026-01-31), and returns the start of the window. Day and
+// week windows start at midnight so the result is stable within a day.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	var n int
+	var unit string
+	if _, err := fmt.Sscanf(value, "%d%s", &n, &unit); err == nil && n >= 0 && (unit == "d" || unit == "w") {
+		days := n
+		if unit == "w" {
+			days *= 7
+		}
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		return midnight.AddDate(0, 0, -days), nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since '%s' (use e.g. 14d, 2w, 36h or 2026-01-31)", value)
+}
+
+// taskTitle returns the first line of a task's prompt.
+func taskTitle(t Task) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(t.Prompt), "\n", 2)[0])
+}
+
+// completedAt approximates when a task was completed.
+func completedAt(t Task) time.Time {
+	if !t.UpdatedAt.IsZero() {
+		return t.UpdatedAt
+	}
+	return t.CreatedAt
+}
+
+// findMergeCommit returns the most recent autom8 merge commit for a task and
+// the worktree it merged, from "Merge autom8/<worktree> (autom8 ...)" subjects.
+func findMergeCommit(gitRoot, taskID string) (string, string) {
+	logCmd := exec.Command("git", "-C", gitRoot, "log", "--merges", "--format=%H%x09%s", "--grep", taskID+"-", "-F")
+	output, err := logCmd.Output()
+	if err != nil {
+		return "", ""
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		hash, subject, ok := strings.Cut(line, "\t")
+		if !ok || !strings.HasPrefix(subject, "Merge autom8/") {
+			continue
+		}
+		worktree, _, _ := strings.Cut(strings.TrimPrefix(subject, "Merge autom8/"), " ")
+		if parseTaskIDFromWorktreeName(worktree) == taskID {
+			return hash, worktree
+		}
+	}
+	return "", ""
+}
+
+// latestIterationLog returns the path of a worktree's highest-numbered
+// iteration log, or "" if it has none.
+func latestIterationLog(logsDir, worktree string) string {
+	matches, _ := filepath.Glob(filepath.Join(logsDir, worktree, "iteration-*.log"))
+	latest, latestN := "", 0
+	for _, path := range matches {
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "iteration-"), ".log"))
+		if err == nil && n > latestN {
+			latest, latestN = path, n
+		}
+	}
+	return latest
+}
+
+// ansiPattern matches terminal escape sequences (CSI, OSC and two-byte
+// escapes) in agent output.
+var ansiPattern = regexp.MustCompile("\x1b(\\[[0-?]*[ -/]*[@-~]|\\][^\x07\x1b]*(\x07|\x1b\\\\)|[@-Z\\\\-_])")
+
+// lastOutputLine returns the last non-empty line of a log with escape
+// sequences and control characters removed. Only the end of the file is
+// read, so huge transcripts don't slow status down.
+func lastOutputLine(path string) string {
+	if path == "" {
+		return ""
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	const tailBytes = 8 << 10
+	if info, err := f.Stat(); err == nil && info.Size() > tailBytes {
+		f.Seek(-tailBytes, io.SeekEnd)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return ""
+	}
+
+	lines := strings.FieldsFunc(ansiPattern.ReplaceAllString(string(data), ""), func(r rune) bool { return r == '\n' || r == '\r' })
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(cleanOutput(lines[i])); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// cleanOutput makes a line of agent output safe to print: escape sequences
+// are removed, and control characters and invalid UTF-8 become spaces.
+func cleanOutput(s string) string {
+	s = strings.ToValidUTF8(ansiPattern.ReplaceAllString(s, ""), " ")
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// printLatestLog prints the last --log-lines lines of a worktree's latest
+// iteration log for describe --logs.
+func printLatestLog(worktree string) {
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return
+	}
+	path := latestIterationLog(filepath.Join(autom8Path, "logs"), worktree)
+	if path == "" {
+		fmt.Printf("      %s (no iteration logs yet)\n", subtitleStyle.Render("Latest output:"))
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	header := filepath.Base(path)
+	if logLines > 0 && len(lines) > logLines {
+		header = fmt.Sprintf("%s, last %d of %d lines", header, logLines, len(lines))
+		lines = lines[len(lines)-logLines:]
+	}
+	fmt.Printf("      %s %s\n", subtitleStyle.Render("Latest output:"), subtitleStyle.Render("("+header+")"))
+	for _, line := range lines {
+		fmt.Printf("        %s\n", line)
+	}
+}
+
+// eventsSchemaVersion is the version of the records in events.jsonl. It is
+// bumped when a field changes meaning; new optional fields keep it.
+const eventsSchemaVersion = 1
+
+// agentEvent is one line of a worktree's events.jsonl, the structured log
+// implement keeps next to the raw iteration-N.log transcripts. Readers
+// skip lines they can't parse, such as one still being written.
+type agentEvent struct {
+	V           int       `json:"v"` // eventsSchemaVersion
+	Time        time.Time `json:"time"`
+	Type        string    `json:"type"` // iteration_start, iteration_end, rate_limited, verify, review or finished
+	Iteration   int       `json:"iteration,omitempty"`
+	PromptBytes int       `json:"prompt_bytes,omitempty"` // iteration_start: size of the prompt arguments
+	ExitCode    *int      `json:"exit_code,omitempty"`    // iteration_end: agent exit code, -1 if killed
+	DurationMS  int64     `json:"duration_ms,omitempty"`  // iteration_end, verify, review, finished (wall time of the run)
+	Marker      bool      `json:"marker,omitempty"`       // iteration_end: output contained TASK COMPLETE
+	Passed      *bool     `json:"passed,omitempty"`       // verify, review
+	CostUSD     float64   `json:"cost_usd,omitempty"`     // iteration_end, when the budget tracks cost
+	Status      string    `json:"status,omitempty"`       // finished: completed, failed, stopped, ...
+	Detail      string    `json:"detail,omitempty"`       // finished: why it failed or stopped
+	Class       string    `json:"class,omitempty"`        // iteration_end, finished: kind of failure, see classifyFailure
+}
+
+// appendEvent adds an event to logsDir/events.jsonl. Only the goroutine
+// working on the worktree writes there, so no lock is needed.
+func appendEvent(logsDir string, ev agentEvent) {
+	ev.V = eventsSchemaVersion
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(logsDir, "events.jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(data, '\n'))
+}
+
+// loadEvents reads logsDir/events.jsonl. Worktrees from before it existed
+// have none.
+func loadEvents(logsDir string) []agentEvent {
+	data, err := os.ReadFile(filepath.Join(logsDir, "events.jsonl"))
+	if err != nil {
+		return nil
+	}
+	var events []agentEvent
+	for _, line := range strings.Split(string(data), "\n") {
+		var ev agentEvent
+		if line != "" && json.Unmarshal([]byte(line), &ev) == nil {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+// iterationLog describes one iteration for the logs command.
+type iterationLog struct {
+	N        int
+	Path     string // "" while the iteration has not written its log
+	Written  time.Time
+	Size     int64
+	Duration time.Duration // 0 if not recorded
+	Running  bool
+	Complete bool
+}
+
+// listIterationLogs returns a worktree's iterations in order, including
+// one that is still running.
+func listIterationLogs(logsDir string) []iterationLog {
+	events := loadEvents(logsDir)
+	byN := make(map[int]*iterationLog)
+	matches, _ := filepath.Glob(filepath.Join(logsDir, "iteration-*.log"))
+	for _, path := range matches {
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "iteration-"), ".log"))
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		log := &iterationLog{N: n, Path: path, Written: info.ModTime(), Size: info.Size()}
+		if events == nil {
+			// Older worktrees have no events to tell whether it completed
+			if data, err := os.ReadFile(path); err == nil {
+				log.Complete = strings.Contains(string(data), "TASK COMPLETE")
+			}
+		}
+		byN[n] = log
+	}
+	for _, ev := range events {
+		if ev.Iteration == 0 || (ev.Type != "iteration_start" && ev.Type != "iteration_end") {
+			continue
+		}
+		log, ok := byN[ev.Iteration]
+		if !ok {
+			log = &iterationLog{N: ev.Iteration}
+			byN[ev.Iteration] = log
+		}
+		if ev.Type == "iteration_start" {
+			log.Running = true
+		} else {
+			log.Running = false
+			log.Duration = time.Duration(ev.DurationMS) * time.Millisecond
+			log.Complete = ev.Marker
+		}
+	}
+
+	logs := make([]iterationLog, 0, len(byN))
+	for _, log := range byN {
+		logs = append(logs, *log)
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].N < logs[j].N })
+	return logs
+}
+
+// describe summarizes an iteration on one line for the list and the picker.
+func (l iterationLog) describe() string {
+	if l.Path == "" {
+		if l.Running {
+			return fmt.Sprintf("iteration %d  running, no log yet", l.N)
+		}
+		return fmt.Sprintf("iteration %d  no log", l.N)
+	}
+	duration := "unknown"
+	if l.Running {
+		duration = "running"
+	} else if l.Duration >= time.Second {
+		duration = l.Duration.Round(time.Second).String()
+	} else if l.Duration > 0 {
+		duration = "<1s"
+	}
+	marker := ""
+	if l.Complete {
+		marker = "  TASK COMPLETE"
+	}
+	return fmt.Sprintf("iteration %d  %s  %s  %s%s", l.N, l.Written.Format("Jan 2 15:04:05"), formatBytes(l.Size), duration, marker)
+}
+
+// reservedLogDirs are the directories in .autom8/logs that don't belong to
+// a worktree.
+var reservedLogDirs = map[string]bool{"hooks": true, "serve": true, "archive": true}
+
+// taskLogDirs returns the names of the worktree log directories in logsRoot
+// that belong to the given tasks.
+func taskLogDirs(logsRoot string, taskIDs map[string]bool) []string {
+	entries, _ := os.ReadDir(logsRoot)
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !reservedLogDirs[entry.Name()] && taskIDs[parseTaskIDFromWorktreeName(entry.Name())] {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// removeLogDirs deletes the named directories in logsRoot. With an archive
+// name they are first packed into logsRoot/archive/<name>-<time>.tar.gz,
+// whose path is returned.
+func removeLogDirs(logsRoot string, names []string, archiveName string) (string, error) {
+	if len(names) == 0 {
+		return "", nil
+	}
+	var archivePath string
+	if archiveName != "" {
+		archivePath = filepath.Join(logsRoot, "archive", fmt.Sprintf("%s-%s.tar.gz", archiveName, time.Now().Format("20060102-150405")))
+		if err := archiveLogDirs(logsRoot, names, archivePath); err != nil {
+			return "", fmt.Errorf("error archiving logs: %w", err)
+		}
+	}
+	for _, name := range names {
+		os.RemoveAll(filepath.Join(logsRoot, name))
+	}
+	return archivePath, nil
+}
+
+// archiveLogDirs writes the named directories in logsRoot to a gzipped
+// tarball at outPath.
+func archiveLogDirs(logsRoot string, names []string, outPath string) error {
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		err = filepath.Walk(filepath.Join(logsRoot, name), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			hdr, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			rel, _ := filepath.Rel(logsRoot, path)
+			hdr.Name = filepath.ToSlash(rel)
+			if info.IsDir() {
+				hdr.Name += "/"
+			}
+			if err := tw.WriteHeader(hdr); err != nil || info.IsDir() {
+				return err
+			}
+			src, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+			_, err = io.Copy(tw, src)
+			return err
+		})
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(outPath)
+	}
+	return err
+}
+
+// dirUsage returns the total size of the files under dir and when the
+// newest of them was written.
+func dirUsage(dir string) (size int64, newest time.Time) {
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+			if info.ModTime().After(newest) {
+				newest = info.ModTime()
+			}
+		}
+		return nil
+	})
+	return size, newest
+}
+
+// runLogsPrune implements 'logs --prune': it removes the logs of worktrees
+// that no longer exist and haven't been written since --older-than.
+func runLogsPrune() error {
+	if olderThanFlag == "" {
+		return fmt.Errorf("--prune needs --older-than, e.g. --older-than 30d")
+	}
+	cutoff, err := parseSince(olderThanFlag, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --older-than '%s' (use e.g. 30d, 2w, 36h or 2026-01-31)", olderThanFlag)
+	}
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	logsRoot := filepath.Join(autom8Path, "logs")
+	entries, err := os.ReadDir(logsRoot)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading logs: %w", err)
+	}
+
+	var names []string
+	var freed int64
+	kept := 0
+	for _, entry := range entries {
+		if !entry.IsDir() || reservedLogDirs[entry.Name()] {
+			continue
+		}
+		size, newest := dirUsage(filepath.Join(logsRoot, entry.Name()))
+		if !newest.Before(cutoff) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(autom8Path, "worktrees", entry.Name())); err == nil {
+			kept++
+			continue
+		}
+		names = append(names, entry.Name())
+		freed += size
+		fmt.Printf("  %s %s %s\n", subtitleStyle.Render(map[bool]string{false: "[removed]", true: "[archived]"}[keepLogsFlag]), entry.Name(),
+			subtitleStyle.Render("(last written "+newest.Format("Jan 2 2006")+", "+formatBytes(size)+")"))
+	}
+
+	if kept > 0 {
+		fmt.Printf("%s kept the old logs of %d worktree(s) that still exist; 'autom8 prune' or 'autom8 delete' removes them with their task\n",
+			subtitleStyle.Render("Note:"), kept)
+	}
+	if len(names) == 0 {
+		fmt.Println(subtitleStyle.Render("No logs older than " + olderThanFlag + " to prune."))
+		return nil
+	}
+
+	archiveName := ""
+	if keepLogsFlag {
+		archiveName = "logs"
+	}
+	archivePath, err := removeLogDirs(logsRoot, names, archiveName)
+	if err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("Pruned the logs of %d worktree(s), freeing %s.", len(names), formatBytes(freed))))
+	if archivePath != "" {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Archived to:"), archivePath)
+	}
+	return nil
+}
+
+// writeCappedLog writes an agent transcript to logFile, dropping its start
+// if the worktree's logs would grow past capBytes (0: unlimited).
+// events.jsonl doesn't count against the cap and is never truncated.
+func writeCappedLog(logFile string, data []byte, capBytes int64) {
+	if capBytes > 0 {
+		var used int64
+		entries, _ := os.ReadDir(filepath.Dir(logFile))
+		for _, entry := range entries {
+			if entry.Name() == "events.jsonl" || entry.Name() == filepath.Base(logFile) {
+				continue
+			}
+			if info, err := entry.Info(); err == nil && !info.IsDir() {
+				used += info.Size()
+			}
+		}
+		if room := max(capBytes-used, 0); int64(len(data)) > room {
+			notice := fmt.Sprintf("[autom8: transcript truncated, first %s of %s dropped to keep this worktree's logs under logs.max_worktree_mb]\n",
+				formatBytes(int64(len(data))-room), formatBytes(int64(len(data))))
+			data = append([]byte(notice), data[int64(len(data))-room:]...)
+		}
+	}
+	os.WriteFile(logFile, data, 0644)
+}
+
+// worktreeLogCap returns logs.max_worktree_mb in bytes (0: unlimited).
+func worktreeLogCap() int64 {
+	cfg, err := loadConfig()
+	if err != nil {
+		return 0 // Reported by loadMCPConfig, which every implement path calls first
+	}
+	return int64(cfg.Logs.MaxWorktreeMB) << 20
+}
+
+// formatBytes renders a file size for listings.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	if pruneLogsFlag {
+		return runLogsPrune()
+	}
+	worktreeName := args[0]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	logsDir := filepath.Join(autom8Path, "logs", worktreeName)
+	if _, err := os.Stat(logsDir); os.IsNotExist(err) {
+		return fmt.Errorf("no logs for worktree '%s'\nRun 'autom8 worktrees' to see available worktrees", worktreeName)
+	}
+
+	if timingsFlag {
+		return printIterationTimings(worktreeName, loadEvents(logsDir))
+	}
+
+	logs := listIterationLogs(logsDir)
+	var written []iterationLog
+	for _, log := range logs {
+		if log.Path != "" {
+			written = append(written, log)
+		}
+	}
+
+	var path string
+	switch {
+	case logIteration > 0:
+		for _, log := range written {
+			if log.N == logIteration {
+				path = log.Path
+			}
+		}
+		if path == "" {
+			return fmt.Errorf("worktree '%s' has no log for iteration %d", worktreeName, logIteration)
+		}
+	case lastLogFlag:
+		if len(written) == 0 {
+			return fmt.Errorf("worktree '%s' has no iteration logs yet", worktreeName)
+		}
+		path = written[len(written)-1].Path
+	default:
+		if len(logs) == 0 {
+			fmt.Println(subtitleStyle.Render("No iteration logs yet."))
+			return nil
+		}
+		if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) || len(written) == 0 {
+			for _, log := range logs {
+				fmt.Println(log.describe())
+			}
+			return nil
+		}
+
+		options := make([]huh.Option[string], 0, len(written))
+		for _, log := range written {
+			options = append(options, huh.NewOption(log.describe(), log.Path))
+		}
+		err := huh.NewSelect[string]().
+			Title(fmt.Sprintf("Iteration logs of %s", worktreeName)).
+			Options(options...).
+			Value(&path).
+			WithTheme(huh.ThemeDracula()).
+			Run()
+		if err != nil {
+			if err == huh.ErrUserAborted {
+				return nil
+			}
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading log: %w", err)
+	}
+	touchWorktree(worktreeName)
+	if isTerminal(os.Stdout) {
+		if err := pipeToLess(data); err == nil {
+			return nil
+		}
+	}
+	os.Stdout.Write(data)
+	return nil
+}
+
+// iterationTiming is where one iteration's time went, from events.jsonl
+type iterationTiming struct {
+	N        int
+	Agent    time.Duration // Summed over rate-limited attempts
+	Verify   time.Duration
+	Retries  int
+	Complete bool
+	Running  bool
+}
+
+// iterationTimings groups a worktree's events by iteration, and returns
+// the time spent in review separately.
+func iterationTimings(events []agentEvent) ([]iterationTiming, time.Duration) {
+	var timings []iterationTiming
+	var review time.Duration
+	for _, ev := range events {
+		if ev.Type == "review" {
+			review += time.Duration(ev.DurationMS) * time.Millisecond
+			continue
+		}
+		if ev.Iteration == 0 {
+			continue
+		}
+		if len(timings) == 0 || timings[len(timings)-1].N != ev.Iteration {
+			timings = append(timings, iterationTiming{N: ev.Iteration})
+		}
+		t := &timings[len(timings)-1]
+		switch ev.Type {
+		case "iteration_start":
+			t.Running = true
+		case "iteration_end":
+			t.Running = false
+			t.Agent += time.Duration(ev.DurationMS) * time.Millisecond
+			t.Complete = ev.Marker
+		case "rate_limited":
+			t.Retries++
+		case "verify":
+			t.Verify += time.Duration(ev.DurationMS) * time.Millisecond
+		}
+	}
+	return timings, review
+}
+
+// durationTrend compares the mean of the later half of the durations with
+// the earlier half, or returns "" with fewer than three.
+func durationTrend(durations []time.Duration) string {
+	if len(durations) < 3 {
+		return ""
+	}
+	mean := func(ds []time.Duration) float64 {
+		var sum time.Duration
+		for _, d := range ds {
+			sum += d
+		}
+		return float64(sum) / float64(len(ds))
+	}
+	half := len(durations) / 2
+	first, last := mean(durations[:half]), mean(durations[len(durations)-half:])
+	if first == 0 {
+		return ""
+	}
+	switch ratio := last / first; {
+	case ratio < 0.8:
+		return fmt.Sprintf("speeding up, later iterations take %.0f%% less time", (1-ratio)*100)
+	case ratio > 1.25:
+		return fmt.Sprintf("slowing down, later iterations take %.0f%% more time", (ratio-1)*100)
+	}
+	return "steady"
+}
+
+// printIterationTimings implements 'logs --timings': a table of each
+// iteration's agent and verify time with a bar per iteration.
+func printIterationTimings(worktreeName string, events []agentEvent) error {
+	timings, review := iterationTimings(events)
+	if len(timings) == 0 {
+		fmt.Println(subtitleStyle.Render("No timings recorded for this worktree (it has no events.jsonl)."))
+		return nil
+	}
+
+	var longest, total time.Duration
+	var finished []time.Duration
+	for _, t := range timings {
+		longest = max(longest, t.Agent)
+		total += t.Agent + t.Verify
+		if !t.Running {
+			finished = append(finished, t.Agent)
+		}
+	}
+
+	fmt.Println(titleStyle.Render("Timings of " + worktreeName))
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  ITERATION\tAGENT\tVERIFY\tRETRIES\t")
+	for _, t := range timings {
+		agent, verify, retries := formatStepDuration(t.Agent), "-", "-"
+		if t.Verify > 0 {
+			verify = formatStepDuration(t.Verify)
+		}
+		if t.Retries > 0 {
+			retries = strconv.Itoa(t.Retries)
+		}
+		bar := ""
+		if longest > 0 {
+			bar = strings.Repeat("█", max(1, int(30*t.Agent/longest)))
+		}
+		switch {
+		case t.Running:
+			agent, bar = "running", ""
+		case t.Complete:
+			bar += " " + successStyle.Render("TASK COMPLETE")
+		}
+		fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\n", t.N, agent, verify, retries, bar)
+	}
+	w.Flush()
+	fmt.Println()
+
+	if review > 0 {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Review:"), formatStepDuration(review))
+		total += review
+	}
+	fmt.Printf("  %s %s over %d iteration(s)\n", subtitleStyle.Render("Total:"), formatStepDuration(total), len(timings))
+	if len(finished) > 0 {
+		var sum time.Duration
+		for _, d := range finished {
+			sum += d
+		}
+		fmt.Printf("  %s %s per iteration\n", subtitleStyle.Render("Mean agent time:"), formatStepDuration(sum/time.Duration(len(finished))))
+	}
+	if trend := durationTrend(finished); trend != "" {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Trend:"), trend)
+	}
+	return nil
+}
+
+// formatStepDuration rounds a duration to whole seconds, or "<1s".
+func formatStepDuration(d time.Duration) string {
+	if d < time.Second {
+		return "<1s"
+	}
+	return d.Round(time.Second).String()
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(autom8Path, "worktrees", worktreeName)); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+	logsDir := filepath.Join(autom8Path, "logs", worktreeName)
+
+	// Polled twice a second, so skip the git calls of getWorktreeInfo
+	agentRunning := func() bool {
+		pids, _ := loadPids()
+		pid, ok := pids[worktreeName]
+		return ok && isProcessRunning(pid)
+	}
+
+	if !agentRunning() {
+		fmt.Printf("No agent is running in %s.\n", worktreeName)
+		if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+			fmt.Printf("See its last iteration with: autom8 logs %s --last\n", worktreeName)
+			return nil
+		}
+		var show bool
+		err := huh.NewConfirm().
+			Title("Show its last iteration log?").
+			Affirmative("Show").
+			Negative("No").
+			Value(&show).
+			WithTheme(huh.ThemeDracula()).
+			Run()
+		if err != nil && err != huh.ErrUserAborted {
+			return err
+		}
+		if !show {
+			return nil
+		}
+		lastLogFlag = true
+		return runLogs(cmd, args)
+	}
+
+	// Events of earlier runs of this worktree are not followed
+	runStart := 0
+	for i, ev := range loadEvents(logsDir) {
+		if ev.Type == "finished" {
+			runStart = i + 1
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	current, offset := 0, int64(0)
+	logPath := func(n int) string { return filepath.Join(logsDir, fmt.Sprintf("iteration-%d.log", n)) }
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		var run []agentEvent
+		if events := loadEvents(logsDir); len(events) > runStart {
+			run = events[runStart:]
+		}
+		latest := 0
+		var latestStart, started time.Time
+		var finished *agentEvent
+		ended := make(map[int]agentEvent)
+		for i, ev := range run {
+			if started.IsZero() {
+				started = ev.Time
+			}
+			switch ev.Type {
+			case "iteration_start":
+				latest, latestStart = ev.Iteration, ev.Time
+			case "iteration_end":
+				ended[ev.Iteration] = ev
+			case "finished":
+				finished = &run[i]
+			}
+		}
+
+		if current > 0 {
+			offset = copyLogFrom(logPath(current), offset)
+		}
+		if latest > current {
+			if end, ok := ended[current]; ok && current > 0 {
+				fmt.Println(formatAttachFooter(end))
+			}
+			current, offset = latest, 0
+			fmt.Println()
+			fmt.Println(titleStyle.Render(fmt.Sprintf("Iteration %d", current)) + " " +
+				subtitleStyle.Render(fmt.Sprintf("%s into the run", latestStart.Sub(started).Round(time.Second))))
+			offset = copyLogFrom(logPath(current), offset)
+		}
+
+		if finished != nil {
+			if end, ok := ended[current]; ok && current > 0 {
+				fmt.Println(formatAttachFooter(end))
+			}
+			fmt.Println()
+			status := finished.Status
+			if finished.Class != "" {
+				status += " (" + finished.Class + ")"
+			}
+			fmt.Printf("%s %s after %d iteration(s), %s\n", successStyle.Render("Agent finished:"), status,
+				finished.Iteration, finished.Time.Sub(started).Round(time.Second))
+			if finished.Detail != "" {
+				fmt.Printf("  %s\n", finished.Detail)
+			}
+			return nil
+		}
+		if !agentRunning() {
+			fmt.Println()
+			fmt.Println(subtitleStyle.Render("The agent is no longer running."))
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			fmt.Println(subtitleStyle.Render("Stopped following; the agent keeps running."))
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// copyLogFrom writes what was added to a log since offset to stdout and
+// returns the new offset. A log that shrank was rewritten and is shown again.
+func copyLogFrom(path string, offset int64) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return offset
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err != nil || info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset
+	}
+	n, _ := io.Copy(os.Stdout, f)
+	return offset + n
+}
+
+// currentRunStart returns when the latest implement run recorded in events
+// began: its first event after the last finished one. It is zero if that run
+// finished too, or there are no events.
+func currentRunStart(events []agentEvent) time.Time {
+	var start time.Time
+	for _, ev := range events {
+		if ev.Type == "finished" {
+			start = time.Time{}
+		} else if start.IsZero() {
+			start = ev.Time
+		}
+	}
+	return start
+}
+
+// worktreeDuration sums the wall time of a worktree's implement runs from its
+// events, e.g. "4m10s" or "4m10s + running for 12s". Runs recorded before
+// finished events carried a duration, and runs whose agent died without
+// finishing, are timed from their first to their last event. A worktree
+// without events shows "unknown".
+func worktreeDuration(events []agentEvent, running bool) string {
+	if len(events) == 0 {
+		return "unknown"
+	}
+	var total time.Duration
+	var runStart time.Time
+	for _, ev := range events {
+		if runStart.IsZero() {
+			runStart = ev.Time
+		}
+		if ev.Type != "finished" {
+			continue
+		}
+		if ev.DurationMS > 0 {
+			total += time.Duration(ev.DurationMS) * time.Millisecond
+		} else {
+			total += ev.Time.Sub(runStart)
+		}
+		runStart = time.Time{}
+	}
+	if !runStart.IsZero() && !running {
+		total += events[len(events)-1].Time.Sub(runStart)
+	} else if !runStart.IsZero() {
+		if total == 0 {
+			return fmt.Sprintf("running for %s", formatStepDuration(time.Since(runStart)))
+		}
+		return fmt.Sprintf("%s + running for %s", formatStepDuration(total), formatStepDuration(time.Since(runStart)))
+	}
+	return formatStepDuration(total)
+}
+
+// formatAttachFooter summarizes a finished iteration for attach.
+func formatAttachFooter(end agentEvent) string {
+	line := fmt.Sprintf("iteration %d finished in %s", end.Iteration, formatStepDuration(time.Duration(end.DurationMS)*time.Millisecond))
+	if end.ExitCode != nil && *end.ExitCode != 0 {
+		line += fmt.Sprintf(", exit code %d", *end.ExitCode)
+	}
+	if end.Marker {
+		return subtitleStyle.Render(line+",") + " " + successStyle.Render("TASK COMPLETE")
+	}
+	return subtitleStyle.Render(line)
+}
+
+func runGrep(cmd *cobra.Command, args []string) error {
+	if logsOnlyFlag && tasksOnlyFlag {
+		return fmt.Errorf("--logs-only and --tasks-only exclude each other")
+	}
+	if grepContext < 0 {
+		return fmt.Errorf("--context must not be negative")
+	}
+
+	pattern := args[0]
+	if fixedStrings {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w\nUse -F to search for it literally", err)
+	}
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	// Log directories by task, including those that outlived their task
+	logsRoot := filepath.Join(autom8Path, "logs")
+	logDirs := make(map[string][]string)
+	var taskIDs []string
+	for _, t := range tasks {
+		taskIDs = append(taskIDs, t.ID)
+	}
+	entries, _ := os.ReadDir(logsRoot)
+	for _, entry := range entries {
+		if !entry.IsDir() || reservedLogDirs[entry.Name()] {
+			continue
+		}
+		id := parseTaskIDFromWorktreeName(entry.Name())
+		if _, seen := logDirs[id]; !seen && findTask(tasks, id) == nil {
+			taskIDs = append(taskIDs, id)
+		}
+		logDirs[id] = append(logDirs[id], entry.Name())
+	}
+
+	if taskFilter != "" {
+		if findTask(tasks, taskFilter) == nil && logDirs[taskFilter] == nil {
+			return ErrTaskNotFound{ID: taskFilter}
+		}
+		taskIDs = []string{taskFilter}
+	}
+
+	g := &grepper{re: re, context: grepContext}
+	matchedTasks := 0
+	for _, id := range taskIDs {
+		before := g.matches
+		task := findTask(tasks, id)
+		if task != nil {
+			g.setHeader(0, idStyle.Render(id)+"  "+truncate(task.Prompt, 60))
+		} else {
+			g.setHeader(0, idStyle.Render(id)+"  "+subtitleStyle.Render("(task deleted)"))
+		}
+
+		if task != nil && !logsOnlyFlag {
+			g.setHeader(1, subtitleStyle.Render("prompt"))
+			g.search(strings.NewReader(task.Prompt))
+			for i, c := range task.VerificationCriteria {
+				g.setHeader(1, subtitleStyle.Render(fmt.Sprintf("criterion %d", i+1)))
+				g.search(strings.NewReader(c))
+			}
+		}
+
+		if !tasksOnlyFlag {
+			for _, name := range logDirs[id] {
+				g.setHeader(1, highlightStyle.Render(name))
+				for _, log := range listIterationLogs(filepath.Join(logsRoot, name)) {
+					if log.Path == "" {
+						continue
+					}
+					g.setHeader(2, subtitleStyle.Render(fmt.Sprintf("iteration %d", log.N)))
+					if err := g.searchFile(log.Path); err != nil {
+						fmt.Fprintf(os.Stderr, "%s could not read %s: %v\n", errorStyle.Render("Warning:"), log.Path, err)
+					}
+				}
+			}
+		}
+		if g.matches > before {
+			matchedTasks++
+		}
+	}
+
+	if g.skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%s skipped %d binary log(s)\n", subtitleStyle.Render("Note:"), g.skipped)
+	}
+	if g.matches == 0 {
+		fmt.Println(subtitleStyle.Render("No matches."))
+		return nil
+	}
+	fmt.Println()
+	fmt.Println(subtitleStyle.Render(fmt.Sprintf("%d matching line(s) in %d task(s)", g.matches, matchedTasks)))
+	return nil
+}
+
+// grepMaxLine bounds how much of a single line grep holds in memory; longer
+// lines (a transcript without newlines) are searched in pieces of this size.
+const grepMaxLine = 256 << 10
+
+// grepper prints matches under nested headers (task, then prompt/criterion
+// or worktree, then iteration), each printed once something under it
+// matches.
+type grepper struct {
+	re      *regexp.Regexp
+	context int
+	headers [3]string
+	printed [3]bool
+	matches int
+	skipped int
+	indent  string
+}
+
+// setHeader replaces the header at level and clears the ones below it.
+func (g *grepper) setHeader(level int, header string) {
+	g.headers[level] = header
+	g.printed[level] = false
+	for l := level + 1; l < len(g.headers); l++ {
+		g.headers[l] = ""
+		g.printed[l] = false
+	}
+}
+
+func (g *grepper) printHeaders() {
+	g.indent = ""
+	for l, header := range g.headers {
+		if header == "" {
+			continue
+		}
+		if !g.printed[l] {
+			if l == 0 && g.matches > 0 {
+				fmt.Println()
+			}
+			fmt.Println(g.indent + header)
+			g.printed[l] = true
+		}
+		g.indent += "  "
+	}
+}
+
+// searchFile searches a log, skipping it if it looks binary (a NUL byte
+// in the first 8000 bytes, as git decides).
+func (g *grepper) searchFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	head, _ := r.Peek(8000)
+	if bytes.IndexByte(head, 0) >= 0 {
+		g.skipped++
+		return nil
+	}
+	return g.search(r)
+}
+
+// search streams r line by line, printing each matching line with up to
+// g.context lines before and after it, and "--" between separate groups.
+// Lines are matched as printed, without escape sequences, so a pattern can't
+// match inside one and colored words still match.
+func (g *grepper) search(r io.Reader) error {
+	type line struct {
+		n    int
+		text string
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 2*grepMaxLine)
+	scanner.Split(scanLongLines)
+
+	var before []line
+	after, last := 0, 0
+	for n := 1; scanner.Scan(); n++ {
+		text := cleanOutput(scanner.Text())
+		loc := g.re.FindStringIndex(text)
+		switch {
+		case loc != nil:
+			g.printHeaders()
+			first := n - len(before)
+			if last > 0 && first > last+1 {
+				fmt.Println(g.indent + subtitleStyle.Render("--"))
+			}
+			for _, b := range before {
+				g.printLine(b.n, '-', b.text, nil)
+			}
+			g.printLine(n, ':', text, loc)
+			g.matches++
+			before = before[:0]
+			after, last = g.context, n
+		case after > 0:
+			g.printLine(n, '-', text, nil)
+			after--
+			last = n
+		case g.context > 0:
+			before = append(before, line{n, text})
+			if len(before) > g.context {
+				before = before[1:]
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// printLine prints a numbered line, ':' marking a match (highlighted, with
+// the line clipped around it) and '-' a line of context.
+func (g *grepper) printLine(n int, sep rune, text string, loc []int) {
+	const width = 160
+	if loc == nil {
+		text = string(clipRunes([]rune(text), width))
+	} else {
+		pre := []rune(text[:loc[0]])
+		match := clipRunes([]rune(text[loc[0]:loc[1]]), width/2)
+		post := []rune(text[loc[1]:])
+		if len(pre) > width/3 {
+			pre = append([]rune("..."), pre[len(pre)-width/3:]...)
+		}
+		post = clipRunes(post, max(width-len(pre)-len(match), 0))
+		text = string(pre) + highlightStyle.Render(string(match)) + string(post)
+	}
+	fmt.Printf("%s%s%c %s\n", g.indent, subtitleStyle.Render(fmt.Sprintf("%5d", n)), sep, text)
+}
+
+// clipRunes shortens r to at most n runes, ending in "..." when clipped.
+func clipRunes(r []rune, n int) []rune {
+	if len(r) <= n {
+		return r
+	}
+	if n <= 3 {
+		return r[:n]
+	}
+	return append(r[:n-3:n-3], []rune("...")...)
+}
+
+// scanLongLines is bufio.ScanLines, except that a line longer than
+// grepMaxLine is returned in pieces instead of failing the scan.
+func scanLongLines(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if advance == 0 && err == nil && len(data) >= grepMaxLine {
+		return grepMaxLine, data[:grepMaxLine], nil
+	}
+	return advance, token, err
+}
+
+// lastActivity summarizes a worktree's latest event for status, e.g.
+// "iteration 3, 2m ago", or "" if it has no events.
+func lastActivity(wt WorktreeInfo) string {
+	logsDir := filepath.Join(filepath.Dir(filepath.Dir(wt.Path)), "logs", wt.Name)
+	events := loadEvents(logsDir)
+	if len(events) == 0 {
+		return ""
+	}
+	last := events[len(events)-1]
+	ago := time.Since(last.Time).Round(time.Second)
+	if ago >= time.Minute {
+		ago = ago.Round(time.Minute)
+	}
+	if last.Iteration == 0 {
+		return fmt.Sprintf("last activity %s ago", ago)
+	}
+	return fmt.Sprintf("iteration %d, last activity %s ago", last.Iteration, ago)
+}
+
+// countIterationLogs returns how many implementation iterations a worktree
+// ran, from its events, or its iteration logs if it has no events.
+func countIterationLogs(logsDir, worktree string) int {
+	events := loadEvents(filepath.Join(logsDir, worktree))
+	if events == nil {
+		matches, _ := filepath.Glob(filepath.Join(logsDir, worktree, "iteration-*.log"))
+		return len(matches)
+	}
+	iterations := make(map[int]bool)
+	for _, ev := range events {
+		if ev.Type == "iteration_start" {
+			iterations[ev.Iteration] = true
+		}
+	}
+	return len(iterations)
+}
+
+func buildReport(tasks []Task, since time.Time, gitRoot, logsDir string) string {
+	sorted := make([]Task, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	titles := make(map[string]string)
+	var completed, inProgress, pending []Task
+	for _, t := range sorted {
+		titles[t.ID] = taskTitle(t)
+		switch t.Status {
+		case "completed":
+			if since.IsZero() || !completedAt(t).Before(since) {
+				completed = append(completed, t)
+			}
+		case "in-progress":
+			inProgress = append(inProgress, t)
+		default:
+			pending = append(pending, t)
+		}
+	}
+	sort.SliceStable(completed, func(i, j int) bool {
+		return completedAt(completed[i]).Before(completedAt(completed[j]))
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# autom8 report\n\n")
+	if since.IsZero() {
+		sb.WriteString("All completed tasks.\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Tasks completed since %s.\n\n", since.Format("2006-01-02")))
+	}
+	sb.WriteString(fmt.Sprintf("- Completed: %d\n- In progress: %d\n- Pending: %d\n\n", len(completed), len(inProgress), len(pending)))
+
+	sb.WriteString("## Completed\n\n")
+	if len(completed) == 0 {
+		sb.WriteString("Nothing completed in this period.\n\n")
+	}
+	for _, t := range completed {
+		sb.WriteString(fmt.Sprintf("### %s\n\n", titles[t.ID]))
+
+		mergeCommit, merged := findMergeCommit(gitRoot, t.ID)
+		winner := t.Winner
+		if winner == "" {
+			winner = merged
+		}
+		if winner == "" {
+			// Fast-forward accepts leave no merge commit; fall back to the only worktree that ran
+			if dirs, _ := filepath.Glob(filepath.Join(logsDir, "*"+t.ID+"-*")); len(dirs) == 1 {
+				winner = filepath.Base(dirs[0])
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("- Completed: %s (%s after creation)\n", completedAt(t).Format("2006-01-02"), formatDays(completedAt(t).Sub(t.CreatedAt))))
+		if winner != "" {
+			sb.WriteString(fmt.Sprintf("- Implementation: `%s`", winner))
+			if n := countIterationLogs(logsDir, winner); n > 0 {
+				sb.WriteString(fmt.Sprintf(", %d iteration(s)", n))
+			}
+			sb.WriteString("\n")
+		}
+		if t.ExternalRef != nil && t.ExternalRef.URL != "" {
+			sb.WriteString(fmt.Sprintf("- Issue: %s\n", t.ExternalRef.URL))
+		}
+		sb.WriteString("\n")
+
+		if body := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(t.Prompt), titles[t.ID])); body != "" {
+			sb.WriteString(body)
+			sb.WriteString("\n\n")
+		}
+
+		if len(t.VerificationCriteria) > 0 {
+			sb.WriteString("**Verification criteria**\n\n")
+			for _, c := range t.VerificationCriteria {
+				sb.WriteString(fmt.Sprintf("- %s\n", c))
+			}
+			sb.WriteString("\n")
+		}
+
+		if mergeCommit != "" {
+			statCmd := exec.Command("git", "-C", gitRoot, "diff", "--stat", mergeCommit+"^1", mergeCommit)
+			if stat, err := statCmd.Output(); err == nil && len(strings.TrimSpace(string(stat))) > 0 {
+				sb.WriteString("**Changes merged**\n\n```\n")
+				sb.Write(stat)
+				sb.WriteString("```\n\n")
+			}
+		}
+	}
+
+	writeList := func(heading string, list []Task, empty string) {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", heading))
+		if len(list) == 0 {
+			sb.WriteString(empty + "\n\n")
+			return
+		}
+		for _, t := range list {
+			sb.WriteString(fmt.Sprintf("- %s", titles[t.ID]))
+			if parent, ok := titles[t.DependsOn]; ok {
+				sb.WriteString(fmt.Sprintf(" (after: %s)", parent))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	writeList("In progress", inProgress, "Nothing in progress.")
+	writeList("Pending", pending, "Nothing pending.")
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// formatDays renders a duration as days and hours, or minutes when short.
+func formatDays(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+// worktreeDetails is the full view printed by 'worktree info'
+type worktreeDetails struct {
+	WorktreeInfo
+	TaskID        string   `json:"task_id"`
+	TaskPrompt    string   `json:"task_prompt,omitempty"`
+	RecentCommits []string `json:"recent_commits"`
+	DiffStat      string   `json:"diff_stat"`
+
+	LastAccessedAt *time.Time     `json:"last_accessed_at,omitempty"`
+	LastOutcome    string         `json:"last_outcome,omitempty"`
+	FailureClass   string         `json:"failure_class,omitempty"`
+	Notes          []worktreeNote `json:"notes,omitempty"`
+}
+
+// worktreeListEntry is one line of 'autom8 worktrees'.
+type worktreeListEntry struct {
+	WorktreeInfo
+	TaskID       string     `json:"task_id"`
+	PID          int        `json:"pid,omitempty"`           // Running worktrees only
+	RunningSince *time.Time `json:"running_since,omitempty"` // Start of the current implement run, from events.jsonl or else the first iteration log
+}
+
+func runWorktrees(cmd *cobra.Command, args []string) error {
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+
+	names, err := listWorktreeNames(worktreesDir)
+	if err != nil {
+		return fmt.Errorf("error reading worktrees: %w", err)
+	}
+
+	pids, _ := loadPids()
+	list := []worktreeListEntry{}
+	for _, name := range names {
+		entry := worktreeListEntry{
+			WorktreeInfo: getWorktreeInfo(worktreesDir, name, pids),
+			TaskID:       parseTaskIDFromWorktreeName(name),
+		}
+		if runningOnly && !entry.IsRunning {
+			continue
+		}
+		if entry.IsRunning {
+			entry.PID = pids[name]
+			logsDir := filepath.Join(autom8Path, "logs", name)
+			events := loadEvents(logsDir)
+			since := currentRunStart(events)
+			if len(events) == 0 {
+				// No events.jsonl (e.g. a run from an older autom8); the
+				// first iteration log was written around the run's start
+				if logs := listIterationLogs(logsDir); len(logs) > 0 {
+					since = logs[0].Written
+				}
+			}
+			if !since.IsZero() {
+				entry.RunningSince = &since
+			}
+		}
+		list = append(list, entry)
+	}
+
+	if jsonFlag {
+		data, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if runningOnly {
+		if isTerminal(os.Stdout) {
+			if len(list) == 0 {
+				fmt.Println(subtitleStyle.Render("No agents are running."))
+				return nil
+			}
+			fmt.Fprintln(tw, "NAME\tTASK\tBRANCH\tPID\tELAPSED")
+		}
+		for _, wt := range list {
+			elapsed := "unknown"
+			if wt.RunningSince != nil {
+				elapsed = formatStepDuration(time.Since(*wt.RunningSince))
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", wt.Name, wt.TaskID, wt.Branch, wt.PID, elapsed)
+		}
+		return tw.Flush()
+	}
+	if isTerminal(os.Stdout) {
+		fmt.Fprintln(tw, "NAME\tTASK\tBRANCH\tAHEAD\tCHANGES\tAGENT")
+	}
+	for _, wt := range list {
+		changes, agent := "clean", "idle"
+		if wt.HasChanges {
+			changes = "modified"
+		}
+		if wt.IsRunning {
+			agent = "running"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", wt.Name, wt.TaskID, wt.Branch, wt.CommitsAhead, changes, agent)
+	}
+	return tw.Flush()
+}
+
+func runWorktreeInfo(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+
+	if _, err := os.Stat(filepath.Join(worktreesDir, worktreeName)); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	pids, _ := loadPids()
+	details := worktreeDetails{
+		WorktreeInfo:  getWorktreeInfo(worktreesDir, worktreeName, pids),
+		TaskID:        parseTaskIDFromWorktreeName(worktreeName),
+		RecentCommits: []string{},
+	}
+
+	if stats, err := loadWorktreeStats(); err == nil {
+		if s, ok := stats[worktreeName]; ok {
+			if !s.LastAccessedAt.IsZero() {
+				details.LastAccessedAt = &s.LastAccessedAt
+			}
+			details.LastOutcome = s.Outcome
+			details.FailureClass = s.FailureClass
+			details.Notes = s.Notes
+		}
+	}
+
+	if tasks, err := loadTasks(); err == nil {
+		for _, t := range tasks {
+			if t.ID == details.TaskID {
+				details.TaskPrompt = t.Prompt
+				break
+			}
+		}
+	}
+
+	logCmd := exec.Command("git", "-C", details.Path, "log", "--oneline", "-5")
+	if logOutput, err := logCmd.Output(); err == nil {
+		for _, line := range strings.Split(strings.TrimSpace(string(logOutput)), "\n") {
+			if line != "" {
+				details.RecentCommits = append(details.RecentCommits, line)
+			}
+		}
+	}
+
+	diffCmd := exec.Command("git", "-C", details.Path, "diff", "--stat", "HEAD")
+	if diffOutput, err := diffCmd.Output(); err == nil {
+		details.DiffStat = strings.TrimRight(string(diffOutput), "\n")
+	}
+
+	if jsonFlag {
+		data, err := json.MarshalIndent(details, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	var runState string
+	switch {
+	case details.IsRunning:
+		runState = statusInProgressStyle.Render("[running]")
+	case details.HasChanges:
+		runState = statusPendingStyle.Render("[uncommitted changes]")
+	default:
+		runState = statusCompletedStyle.Render("[idle]")
+	}
+
+	fmt.Println(titleStyle.Render("Worktree Details"))
+	fmt.Println()
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Name:"), highlightStyle.Render(details.Name))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("State:"), runState)
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Task:"), idStyle.Render(details.TaskID))
+	if details.TaskPrompt != "" {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Prompt:"), truncate(details.TaskPrompt, 60))
+	}
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Branch:"), details.Branch)
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Path:"), details.Path)
+	fmt.Printf("  %s %s\n", subtitleStyle.Render(fmt.Sprintf("Commits ahead of %s:", defaultBranch(details.Path))), details.CommitsAhead)
+	if details.FailureClass != "" {
+		fmt.Printf("  %s %s (%s)\n", subtitleStyle.Render("Last implement run:"), details.LastOutcome, details.FailureClass)
+	} else if details.LastOutcome != "" {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Last implement run:"), details.LastOutcome)
+	}
+	if details.LastAccessedAt != nil {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Last accessed:"), details.LastAccessedAt.Format("2006-01-02 15:04:05"))
+	}
+	for _, n := range details.Notes {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Note:"), n.Text)
+	}
+	fmt.Println()
+
+	fmt.Println(subtitleStyle.Render("  Recent Commits:"))
+	if len(details.RecentCommits) == 0 {
+		fmt.Println("    (none)")
+	}
+	for _, c := range details.RecentCommits {
+		fmt.Printf("    %s\n", c)
+	}
+	fmt.Println()
+
+	fmt.Println(subtitleStyle.Render("  Uncommitted Changes:"))
+	if details.DiffStat == "" {
+		fmt.Println("    (none)")
+	}
+	for _, line := range strings.Split(details.DiffStat, "\n") {
+		if line != "" {
+			fmt.Printf("    %s\n", strings.TrimSpace(line))
+		}
+	}
+
+	return nil
+}
+
+func runAnnotate(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+	if clearNotesFlag && len(args) > 1 {
+		return fmt.Errorf("--clear removes the notes; drop the text")
+	}
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(autom8Path, "worktrees", worktreeName)); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	switch {
+	case clearNotesFlag:
+		if err := updateWorktreeStats(worktreeName, func(s *worktreeStats) { s.Notes = nil }); err != nil {
+			return fmt.Errorf("error updating worktree stats: %w", err)
+		}
+		fmt.Printf("%s %s\n", successStyle.Render("Cleared notes:"), worktreeName)
+	case len(args) > 1:
+		text := strings.TrimSpace(args[1])
+		if text == "" {
+			return fmt.Errorf("note cannot be empty")
+		}
+		err := updateWorktreeStats(worktreeName, func(s *worktreeStats) {
+			s.Notes = append(s.Notes, worktreeNote{Text: text, At: time.Now()})
+		})
+		if err != nil {
+			return fmt.Errorf("error updating worktree stats: %w", err)
+		}
+		fmt.Printf("%s %s\n", successStyle.Render("Noted:"), worktreeName)
+	default:
+		stats, err := loadWorktreeStats()
+		if err != nil {
+			return fmt.Errorf("error loading worktree stats: %w", err)
+		}
+		notes := stats[worktreeName].Notes
+		if len(notes) == 0 {
+			fmt.Println(subtitleStyle.Render(fmt.Sprintf("No notes on %s. Add one with 'autom8 annotate %s <text>'.", worktreeName, worktreeName)))
+			return nil
+		}
+		for _, n := range notes {
+			fmt.Printf("%s %s\n", subtitleStyle.Render(n.At.Format("2006-01-02 15:04")), n.Text)
+		}
+	}
+	return nil
+}
+
+func runWorktreeTouch(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(autom8Path, "worktrees", worktreeName)); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	if err := touchWorktree(worktreeName); err != nil {
+		return fmt.Errorf("error updating worktree stats: %w", err)
+	}
+	fmt.Printf("%s %s\n", successStyle.Render("Touched:"), worktreeName)
+	return nil
+}
+
+// worktreeManifest is the MANIFEST.json written into worktree exports.
+type worktreeManifest struct {
+	Worktree             string    `json:"worktree"`
+	TaskID               string    `json:"task_id"`
+	Prompt               string    `json:"prompt,omitempty"`
+	VerificationCriteria []string  `json:"verification_criteria,omitempty"`
+	Branch               string    `json:"branch"`
+	Commit               string    `json:"commit"`
+	CommitsAhead         string    `json:"commits_ahead"`
+	ExportedAt           time.Time `json:"exported_at"`
+}
+
+func runWorktreeDiff(cmd *cobra.Command, args []string) error {
+	worktreeName := args[0]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	// Pin the options user config could change, so the patch is always the same
+	diffCmd := exec.Command("git", "-C", worktreePath, "diff", "--no-color", "--no-ext-diff", defaultBranch(gitRoot)+"...HEAD")
+	diffCmd.Stdout = os.Stdout
+	diffCmd.Stderr = os.Stderr
+	if err := diffCmd.Run(); err != nil {
+		return fmt.Errorf("error getting diff: %w", err)
+	}
+	return nil
+}
+
+func runWorktreeExport(cmd *cobra.Command, args []string) error {
+	worktreeName, outPath := args[0], args[1]
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	if _, err := os.Stat(filepath.Join(worktreesDir, worktreeName)); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	pids, _ := loadPids()
+	info := getWorktreeInfo(worktreesDir, worktreeName, pids)
+	manifest := worktreeManifest{
+		Worktree:     worktreeName,
+		TaskID:       parseTaskIDFromWorktreeName(worktreeName),
+		Branch:       info.Branch,
+		Commit:       headCommit(info.Path),
+		CommitsAhead: info.CommitsAhead,
+		ExportedAt:   time.Now(),
+	}
+	if tasks, err := loadTasks(); err == nil {
+		if t := findTask(tasks, manifest.TaskID); t != nil {
+			manifest.Prompt = t.Prompt
+			manifest.VerificationCriteria = t.VerificationCriteria
+		}
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// git archive writes the tree; it is re-packed to add the manifest
+	archiveCmd := exec.Command("git", "-C", info.Path, "archive", "--format=tar", "--prefix="+worktreeName+"/", "HEAD")
+	var stderr bytes.Buffer
+	archiveCmd.Stderr = &stderr
+	archive, err := archiveCmd.Output()
+	if err != nil {
+		return fmt.Errorf("error archiving worktree: %w\n%s", err, stderr.String())
+	}
+
+	// Write next to the destination and rename, so a failure leaves no partial file
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), ".autom8-export-*")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", outPath, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	tw := tar.NewWriter(gz)
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("error reading archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			tmp.Close()
+			return fmt.Errorf("error writing %s: %w", outPath, err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			tmp.Close()
+			return fmt.Errorf("error writing %s: %w", outPath, err)
+		}
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:    worktreeName + "/MANIFEST.json",
+		Mode:    0644,
+		Size:    int64(len(manifestData)),
+		ModTime: manifest.ExportedAt,
+	})
+	if err == nil {
+		_, err = tw.Write(manifestData)
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", outPath, err)
+	}
+	os.Chmod(tmp.Name(), 0644)
+	if err := os.Rename(tmp.Name(), outPath); err != nil {
+		return fmt.Errorf("error writing %s: %w", outPath, err)
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("Exported %s to %s", worktreeName, outPath)))
+	fmt.Printf("  %s %s (%s commit(s) ahead of %s)\n", subtitleStyle.Render("Commit:"), manifest.Commit, manifest.CommitsAhead, defaultBranch(info.Path))
+	if info.HasChanges {
+		fmt.Printf("%s the worktree has uncommitted changes, which are not included\n", errorStyle.Render("Warning:"))
+	}
+	return nil
+}
+
+func runWorktreeRename(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+
+	if newName == oldName {
+		return fmt.Errorf("worktree is already named '%s'", newName)
+	}
+	if newName == "" || strings.ContainsAny(newName, "/\\ ") || strings.HasPrefix(newName, ".") {
+		return fmt.Errorf("invalid worktree name '%s'", newName)
+	}
+	taskID := parseTaskIDFromWorktreeName(oldName)
+	instance := oldName[strings.Index(oldName, taskID):] // Task ID plus instance suffix, without any label
+	if !strings.HasSuffix(newName, instance) {
+		return fmt.Errorf("new name must end with '%s' to stay linked to task '%s'", instance, taskID)
+	}
+
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error getting autom8 dir: %w", err)
+	}
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	oldPath := filepath.Join(worktreesDir, oldName)
+	newPath := filepath.Join(worktreesDir, newName)
+
+	if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: oldName}
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("worktree '%s' already exists", newName)
+	}
+
+	pids, _ := loadPids()
+	if pid, ok := pids[oldName]; ok && isProcessRunning(pid) {
+		return fmt.Errorf("worktree '%s' has a running agent (pid %d); stop it first", oldName, pid)
+	}
+	jobs, err := loadQueue()
+	if err != nil {
+		return fmt.Errorf("error loading queue: %w", err)
+	}
+	for _, job := range jobs {
+		if job.Worktree == oldName {
+			return fmt.Errorf("worktree '%s' is queued; cancel it with 'autom8 queue cancel %s' first", oldName, oldName)
+		}
+	}
+
+	branchOutput, err := exec.Command("git", "-C", oldPath, "branch", "--show-current").Output()
+	if err != nil {
+		return fmt.Errorf("error getting worktree branch: %w", err)
+	}
+	oldBranch := strings.TrimSpace(string(branchOutput))
+	newBranch := "autom8/" + newName
+	if exec.Command("git", "-C", oldPath, "rev-parse", "--verify", "--quiet", "refs/heads/"+newBranch).Run() == nil {
+		return fmt.Errorf("branch '%s' already exists", newBranch)
+	}
+
+	// os.Rename leaves git's worktree links pointing at the old path; repair fixes both ends
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("error renaming worktree directory: %w", err)
+	}
+	if output, err := exec.Command("git", "-C", newPath, "worktree", "repair").CombinedOutput(); err != nil {
+		os.Rename(newPath, oldPath)
+		return fmt.Errorf("error repairing worktree links: %s", strings.TrimSpace(string(output)))
+	}
+	if oldBranch != "" {
+		if output, err := exec.Command("git", "-C", newPath, "branch", "-m", oldBranch, newBranch).CombinedOutput(); err != nil {
+			// Move the directory back so the worktree keeps matching its branch
+			if err := os.Rename(newPath, oldPath); err != nil {
+				return fmt.Errorf("renaming branch '%s' failed (%s) and the worktree could not be moved back from '%s': %w", oldBranch, strings.TrimSpace(string(output)), newPath, err)
+			}
+			if repairOutput, err := exec.Command("git", "-C", oldPath, "worktree", "repair").CombinedOutput(); err != nil {
+				return fmt.Errorf("renaming branch '%s' failed (%s) and repairing the moved-back worktree failed: %s", oldBranch, strings.TrimSpace(string(output)), strings.TrimSpace(string(repairOutput)))
+			}
+			return fmt.Errorf("error renaming branch '%s': %s", oldBranch, strings.TrimSpace(string(output)))
+		}
+	}
+
+	// Everything below is keyed by worktree name; failures only warn since the rename itself is done
+	logsDir := filepath.Join(autom8Path, "logs")
+	if _, err := os.Stat(filepath.Join(logsDir, oldName)); err == nil {
+		if err := os.Rename(filepath.Join(logsDir, oldName), filepath.Join(logsDir, newName)); err != nil {
+			fmt.Printf("%s could not move logs: %v\n", errorStyle.Render("Warning:"), err)
+		}
+	}
+
+	pidsMu.Lock()
+	if pid, ok := pids[oldName]; ok {
+		delete(pids, oldName)
+		pids[newName] = pid
+		if err := savePids(pids); err != nil {
+			fmt.Printf("%s could not update %s: %v\n", errorStyle.Render("Warning:"), pidsFile, err)
+		}
+	}
+	pidsMu.Unlock()
+
+	if err := renameWorktreeStats(oldName, newName); err != nil {
+		fmt.Printf("%s could not update %s: %v\n", errorStyle.Render("Warning:"), statsFile, err)
+	}
+
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		changed := false
+		for i, t := range tasks {
+			if t.Winner == oldName {
+				tasks[i].Winner = newName
+				tasks[i].UpdatedAt = time.Now()
+				changed = true
+			}
+			if score, ok := t.WorktreeScores[oldName]; ok {
+				delete(tasks[i].WorktreeScores, oldName)
+				tasks[i].WorktreeScores[newName] = score
+				changed = true
+			}
+		}
+		if !changed {
+			return nil, nil
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		fmt.Printf("%s could not update the converge winner: %v\n", errorStyle.Render("Warning:"), err)
+	}
+
+	fmt.Printf("%s %s → %s\n", successStyle.Render("Renamed:"), oldName, highlightStyle.Render(newName))
+	if oldBranch != "" {
+		fmt.Printf("  %s %s → %s\n", subtitleStyle.Render("Branch:"), oldBranch, newBranch)
+	}
+	return nil
+}
+
+func runDescribe(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	// Find the task
+	var task *Task
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			task = &tasks[i]
+			break
+		}
+	}
+
+	if task == nil {
+		return ErrTaskNotFound{ID: taskID}
+	}
+
+	// Build task map for dependency lookup
+	taskMap := make(map[string]Task)
+	for _, t := range tasks {
+		taskMap[t.ID] = t
+	}
+
+	// Find dependent tasks
+	var dependents []string
+	for _, t := range tasks {
+		if t.DependsOn == taskID {
+			dependents = append(dependents, t.ID)
+		}
+	}
+
+	// Get worktrees for this task
+	worktrees := loadWorktreesByTask()[taskID]
+
+	// Display task information
+	fmt.Println(titleStyle.Render("Task Details"))
+	fmt.Println()
+
+	// Status badge
+	var statusBadge string
+	switch task.Status {
+	case "pending":
+		statusBadge = statusPendingStyle.Render("[pending]")
+	case "in-progress":
+		statusBadge = statusInProgressStyle.Render("[in-progress]")
+	case "completed":
+		statusBadge = statusCompletedStyle.Render("[completed]")
+	default:
+		statusBadge = subtitleStyle.Render(fmt.Sprintf("[%s]", task.Status))
+	}
+
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Status:"), statusBadge)
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Created:"), task.CreatedAt.Format("2006-01-02 15:04:05"))
+	if !task.UpdatedAt.IsZero() {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Updated:"), task.UpdatedAt.Format("2006-01-02 15:04:05"))
+	}
+	if task.ExternalRef != nil {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Issue:"), highlightStyle.Render(task.ExternalRef.URL))
+	}
+	agentTemplate, _ := loadAgentTemplate("implementer")
+	tokens := estimateTokens(buildImplementPrompt(*task, agentTemplate, ""))
+	promptSize := fmt.Sprintf("~%d tokens (with implementer template)", tokens)
+	if tokens > promptTokenWarning {
+		promptSize = errorStyle.Render(promptSize + ", may exceed model limits")
+	}
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Prompt size:"), promptSize)
+	fmt.Println()
+
+	// Prompt (full, not truncated)
+	fmt.Println(subtitleStyle.Render("  Prompt:"))
+	for _, line := range strings.Split(task.Prompt, "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+	fmt.Println()
+
+	// Verification criteria
+	if len(task.VerificationCriteria) > 0 {
+		fmt.Println(subtitleStyle.Render("  Verification Criteria:"))
+		for i, c := range task.VerificationCriteria {
+			fmt.Printf("    %d. %s\n", i+1, c)
+		}
+		fmt.Println()
+	}
+	if task.VerifyCommand != "" {
+		fmt.Println(subtitleStyle.Render("  Verify Command:"))
+		fmt.Printf("    %s\n", highlightStyle.Render(task.VerifyCommand))
+		fmt.Println()
+	}
+
+	// Dependencies
+	if task.DependsOn != "" {
+		parentTask := taskMap[task.DependsOn]
+		fmt.Println(subtitleStyle.Render("  Depends On:"))
+		fmt.Printf("    %s - %s\n", idStyle.Render(task.DependsOn), truncate(parentTask.Prompt, 50))
+		fmt.Println()
+	}
+
+	// Dependent tasks
+	if len(dependents) > 0 {
+		fmt.Println(subtitleStyle.Render("  Dependents:"))
+		for _, depID := range dependents {
+			depTask := taskMap[depID]
+			fmt.Printf("    %s - %s\n", idStyle.Render(depID), truncate(depTask.Prompt, 50))
+		}
+		fmt.Println()
+	}
+
+	// Worktrees
+	if len(worktrees) > 0 {
+		notes, _ := loadWorktreeStats()
+		fmt.Println(subtitleStyle.Render("  Worktrees:"))
+		for _, wt := range worktrees {
+			var wtStatus string
+			if wt.IsRunning {
+				wtStatus = statusInProgressStyle.Render("[running]")
+			} else if wt.HasChanges {
+				wtStatus = statusPendingStyle.Render("[modified]")
+			} else if wt.CommitsAhead != "0" {
+				wtStatus = statusCompletedStyle.Render("[" + wt.CommitsAhead + " commits]")
+			} else {
+				wtStatus = subtitleStyle.Render("[idle]")
+			}
+			fmt.Printf("    %s %s\n", wtStatus, wt.Name)
+			fmt.Printf("      %s %s\n", subtitleStyle.Render("Branch:"), highlightStyle.Render(wt.Branch))
+			fmt.Printf("      %s %s\n", subtitleStyle.Render("Path:"), wt.Path)
+			for _, n := range notes[wt.Name].Notes {
+				fmt.Printf("      %s %s\n", subtitleStyle.Render("Note:"), n.Text)
+			}
+			fmt.Printf("      %s %s\n", subtitleStyle.Render("Duration:"), worktreeDuration(loadEvents(filepath.Join(filepath.Dir(filepath.Dir(wt.Path)), "logs", wt.Name)), wt.IsRunning))
+			if logsFlag {
+				printLatestLog(wt.Name)
+			}
+		}
+	} else if task.Status == "pending" {
+		fmt.Println(subtitleStyle.Render("  Worktrees:"))
+		fmt.Println("    (none - run 'autom8 implement' to start)")
+	}
+
+	fmt.Println()
+	return nil
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	task := findTask(tasks, taskID)
+	if task == nil {
+		return ErrTaskNotFound{ID: taskID}
+	}
+
+	flags := cmd.Flags()
+	for _, name := range []string{"prompt", "criteria", "add-criteria", "verify-command", "depends-on", "clear-depends-on", "epic"} {
+		if flags.Changed(name) {
+			return editTaskFromFlags(cmd, taskID)
+		}
+	}
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return fmt.Errorf("no terminal for the interactive editor; change the task with flags instead:\n" +
+			"  -p, --prompt <text>          Replace the prompt\n" +
+			"  -c, --criteria <text>        Replace the verification criteria (repeatable)\n" +
+			"      --add-criteria <text>    Append a verification criterion (repeatable)\n" +
+			"      --verify-command <cmd>   Set the verify command (\"\" removes it)\n" +
+			"  -d, --depends-on <task-id>   Set the dependency\n" +
+			"      --clear-depends-on       Remove the dependency\n" +
+			"      --allow-completed-dep    Allow depending on a completed task\n" +
+			"      --epic <name>            Move the task to an epic (\"\" removes it)")
+	}
+
+	// Prepare current values for editing
+	prompt := task.Prompt
+	criteriaInput := strings.Join(task.VerificationCriteria, "\n")
+	dependsOn := task.DependsOn
+	epic := task.Epic
+
+	// Build dependency options (exclude current task to prevent self-reference)
+	dependsOnOptions := []huh.Option[string]{
+		huh.NewOption[string]("None (independent task)", ""),
+	}
+	for _, t := range tasks {
+		if t.ID != taskID { // Can't depend on itself
+			label := fmt.Sprintf("%s - %s", t.ID, truncate(t.Prompt, 40))
+			dependsOnOptions = append(dependsOnOptions, huh.NewOption[string](label, t.ID))
+		}
+	}
+
+	// Interactive editing with huh
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title("Task Prompt").
+				Description("What should the AI implement?").
+				Value(&prompt).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("prompt cannot be empty")
+					}
+					return nil
+				}),
+		),
+		huh.NewGroup(
+			huh.NewText().
+				Title("Verification Criteria").
+				Description("How should success be verified? (one per line, optional)").
+				Value(&criteriaInput),
+		),
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Depends On").
+				Description("Select a task this depends on (optional)").
+				Options(dependsOnOptions...).
+				Value(&dependsOn),
+		),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Epic").
+				Description("Group related tasks under a name (optional)").
+				Value(&epic),
+		),
+	).WithTheme(huh.ThemeDracula())
+
+	err = form.Run()
+	if err != nil {
+		if err == huh.ErrUserAborted {
+			fmt.Println("\nAborted. No changes made.")
+			return nil
+		}
+		return err
+	}
+
+	// Parse criteria from multiline input
+	var criteria []string
+	if strings.TrimSpace(criteriaInput) != "" {
+		for _, line := range strings.Split(criteriaInput, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				criteria = append(criteria, line)
+			}
+		}
+	}
+
+	// Apply only the fields changed in the form, so edits saved by other
+	// processes while it was open are kept
+	original := *task
+	epic = strings.TrimSpace(epic)
+	err = updateTasks(func(tasks []Task) ([]Task, error) {
+		task := findTask(tasks, taskID)
+		if task == nil {
+			return nil, ErrTaskNotFound{ID: taskID}
+		}
+		if prompt != original.Prompt {
+			task.Prompt = prompt
+		}
+		if strings.Join(criteria, "\n") != strings.Join(original.VerificationCriteria, "\n") {
+			task.VerificationCriteria = criteria
+		}
+		if dependsOn != original.DependsOn {
+			if dependsOn != "" {
+				if err := checkDependency(tasks, taskID, dependsOn, allowDoneDep); err != nil {
+					return nil, err
+				}
+			}
+			task.DependsOn = dependsOn
+		}
+		if epic != original.Epic {
+			task.Epic = epic
+		}
+		task.UpdatedAt = time.Now()
+		return tasks, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(successStyle.Render("Task updated successfully!"))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
+	return nil
+}
+
+// editTaskFromFlags applies edit's flags to a task without the interactive
+// editor.
+func editTaskFromFlags(cmd *cobra.Command, taskID string) error {
+	flags := cmd.Flags()
+	if flags.Changed("depends-on") && clearDependsOn {
+		return fmt.Errorf("--depends-on and --clear-depends-on cannot be combined")
+	}
+
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		task := findTask(tasks, taskID)
+		if task == nil {
+			return nil, ErrTaskNotFound{ID: taskID}
+		}
+		if flags.Changed("prompt") {
+			if strings.TrimSpace(promptFlag) == "" {
+				return nil, fmt.Errorf("prompt cannot be empty")
+			}
+			task.Prompt = promptFlag
+		}
+		if flags.Changed("criteria") {
+			task.VerificationCriteria = nil
+			for _, c := range criteriaFlags {
+				if c = strings.TrimSpace(c); c != "" {
+					task.VerificationCriteria = append(task.VerificationCriteria, c)
+				}
+			}
+		}
+		for _, c := range addCriteriaFlags {
+			if c = strings.TrimSpace(c); c != "" {
+				task.VerificationCriteria = append(task.VerificationCriteria, c)
+			}
+		}
+		if flags.Changed("verify-command") {
+			task.VerifyCommand = strings.TrimSpace(verifyCmdFlag)
+		}
+		if flags.Changed("depends-on") && dependsOnFlag != task.DependsOn {
+			if err := checkDependency(tasks, taskID, dependsOnFlag, allowDoneDep); err != nil {
+				return nil, err
+			}
+			task.DependsOn = dependsOnFlag
+		}
+		if clearDependsOn {
+			task.DependsOn = ""
+		}
+		if flags.Changed("epic") {
+			task.Epic = strings.TrimSpace(epicFlag)
+		}
+		task.UpdatedAt = time.Now()
+		return tasks, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(successStyle.Render("Task updated successfully!"))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(taskID))
+	return nil
+}
+
+// orderByDependency returns tasks with every parent ahead of the tasks
+// that depend on it; parents outside the slice are treated as satisfied.
+func orderByDependency(tasks []Task) []Task {
+	pending := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		pending[task.ID] = true
+	}
+	ordered := make([]Task, 0, len(tasks))
+	for len(ordered) < len(tasks) {
+		progressed := false
+		for _, task := range tasks {
+			if !pending[task.ID] || pending[task.DependsOn] {
+				continue
+			}
+			ordered = append(ordered, task)
+			delete(pending, task.ID)
+			progressed = true
+		}
+		if !progressed {
+			// Cycles can't be created through the CLI; keep the rest as is
+			for _, task := range tasks {
+				if pending[task.ID] {
+					ordered = append(ordered, task)
+				}
+			}
+			break
+		}
+	}
+	return ordered
+}
+
+// checkDependency reports whether taskID ("" for a new task) may depend on
+// dependsOn: the task must exist, must not (indirectly) depend on taskID,
+// and must not be completed unless allowCompleted, since its dependent would
+// start from a branch that was already merged.
+func checkDependency(tasks []Task, taskID, dependsOn string, allowCompleted bool) error {
+	if dependsOn == "" {
+		return nil
+	}
+	if dependsOn == taskID {
+		return fmt.Errorf("task cannot depend on itself")
+	}
+	parent := findTask(tasks, dependsOn)
+	if parent == nil {
+		return fmt.Errorf("dependency %w", ErrTaskNotFound{ID: dependsOn})
+	}
+	if parent.Status == "completed" && !allowCompleted {
+		return fmt.Errorf("task '%s' is already completed; depend on it anyway with --allow-completed-dep (allow_completed_dep in the API)", dependsOn)
+	}
+	seen := make(map[string]bool)
+	for id := dependsOn; id != "" && !seen[id]; {
+		if id == taskID {
+			return fmt.Errorf("'%s' already depends on '%s'; that would create a cycle", dependsOn, taskID)
+		}
+		seen[id] = true
+		dep := findTask(tasks, id)
+		if dep == nil {
+			break
+		}
+		id = dep.DependsOn
+	}
+	return nil
+}
+
+func runConverge(cmd *cobra.Command, args []string) error {
+	if diffContext < 0 {
+		return fmt.Errorf("--context must not be negative")
+	}
+	if aiRetries < 0 {
+		return fmt.Errorf("--ai-retries must not be negative")
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println(subtitleStyle.Render("No tasks found."))
+		return nil
+	}
+
+	mcpConfig, err := loadMCPConfig(gitRoot)
+	if err != nil {
+		return err
+	}
+
+	// Check if a specific task ID was provided
+	var targetTaskID string
+	if len(args) > 0 {
+		targetTaskID = args[0]
+	}
+
+	autom8Path, _ := getAutom8Dir()
+	worktreesByTask := loadWorktreesByTask()
+
+	// Filter tasks to converge
+	var tasksToConverge []Task
+	for _, task := range tasks {
+		if targetTaskID != "" {
+			if task.ID == targetTaskID {
+				tasksToConverge = append(tasksToConverge, task)
+				break
+			}
+		} else {
+			// Only converge tasks with multiple worktrees
+			if len(worktreesByTask[task.ID]) > 1 {
+				tasksToConverge = append(tasksToConverge, task)
+			}
+		}
+	}
+
+	if targetTaskID != "" && len(tasksToConverge) == 0 {
+		return ErrTaskNotFound{ID: targetTaskID}
+	}
+
+	if len(tasksToConverge) == 0 {
+		fmt.Println(subtitleStyle.Render("No tasks with multiple worktrees to converge."))
+		return nil
+	}
+
+	excluded := make(map[string]bool)
+	for _, name := range excludeFlags {
+		excluded[name] = true
+	}
+	for name := range excluded {
+		found := false
+		for _, task := range tasksToConverge {
+			for _, wt := range worktreesByTask[task.ID] {
+				found = found || wt.Name == name
+			}
+		}
+		if !found {
+			return fmt.Errorf("--exclude '%s' is not a worktree of the task(s) being converged\nRun 'autom8 status' to see available worktrees", name)
+		}
+	}
+
+	if waitFlag {
+		if pollInterval <= 0 {
+			return fmt.Errorf("--poll-interval must be positive")
+		}
+		worktreesByTask = waitForAgents(tasksToConverge, worktreesByTask)
+	}
+
+	fmt.Println(titleStyle.Render("Converging Implementations"))
+	fmt.Println()
+
+	issues := newIssueSync(gitRoot)
+	notify := newNotifier(nil)
+	conv := &converger{
+		gitRoot:    gitRoot,
+		autom8Path: autom8Path,
+		mcpConfig:  mcpConfig,
+		refresh:    refreshFlag,
+		aiRetries:  aiRetries,
+		explain:    explainFlag,
+		merge:      mergeFlag,
+		issues:     issues,
+		notes:      convergeNotes(),
+		notify:     notify,
+		logf:       func(format string, args ...any) { fmt.Printf(format+"\n", args...) },
+	}
+	var winners int
+	var merged []string
+
+	// Process each task
+	for _, task := range tasksToConverge {
+		var worktrees []WorktreeInfo
+		for _, wt := range worktreesByTask[task.ID] {
+			if excluded[wt.Name] {
+				fmt.Printf("  %s %s\n", subtitleStyle.Render("[excluded]"), wt.Name)
+				continue
+			}
+			worktrees = append(worktrees, wt)
+		}
+
+		if len(worktrees) == 0 {
+			fmt.Printf("  %s %s (no worktrees)\n", subtitleStyle.Render("[skip]"), task.ID)
+			continue
+		}
+
+		if len(worktrees) == 1 {
+			fmt.Printf("  %s %s (only one worktree, nothing to compare)\n", subtitleStyle.Render("[skip]"), task.ID)
+			continue
+		}
+
+		// Check if any worktrees are still running
+		anyRunning := false
+		for _, wt := range worktrees {
+			if wt.IsRunning {
+				anyRunning = true
+				break
+			}
+		}
+		if anyRunning {
+			fmt.Printf("  %s %s (agents still running)\n", statusInProgressStyle.Render("[wait]"), task.ID)
+			continue
+		}
+
+		if topFlag > 0 {
+			total := len(worktrees)
+			worktrees = topWorktreesByCommits(worktrees, topFlag)
+			if len(worktrees) < 2 {
+				fmt.Printf("  %s %s (fewer than two worktrees with commits)\n", subtitleStyle.Render("[skip]"), task.ID)
+				continue
+			}
+			if len(worktrees) < total {
+				fmt.Printf("  %s %s (comparing top %d of %d by commits ahead)\n", subtitleStyle.Render("[top]"), task.ID, len(worktrees), total)
+			}
+		}
+
+		winner := conv.converge(task, worktrees, tasks)
+		if winner != "" {
+			winners++
+			if err := saveConvergeResult(tasks, task.ID); err != nil {
+				return fmt.Errorf("error saving tasks: %w", err)
+			}
+		}
+		if t := findTask(tasks, task.ID); winner != "" && mergeFlag && t.Status == "completed" {
+			merged = append(merged, task.ID)
+		}
+
+		fmt.Println()
+	}
+
+	issues.flush()
+
+	notify.finish("autom8 converge finished", fmt.Sprintf("%d winner(s) chosen for %d task(s)", winners, len(tasksToConverge)))
+
+	fmt.Println(successStyle.Render("Convergence complete!"))
+	if !mergeFlag {
+		fmt.Println(subtitleStyle.Render("Use 'autom8 accept <worktree>' to merge the winner, or 'autom8 converge --merge' to auto-merge."))
+	}
+
+	startDependents(merged, "")
+	return nil
+}
+
+// converger runs the converge analysis for one task at a time. It is shared
+// by converge and implement --auto-converge, which prints through the
+// progress display instead of stdout.
+type converger struct {
+	gitRoot    string
+	autom8Path string
+	mcpConfig  string
+	refresh    bool                     // Ignore cached analyses
+	aiRetries  int                      // Extra attempts when the response names no winner
+	notes      map[string]worktreeStats // With --with-notes, the worktrees' annotate notes
+	explain    bool                     // Save the full reasoning
+	merge      bool                     // Accept the winner
+	issues     *issueSync
+	notify     *notifier
+	logf       func(format string, args ...any)
+}
+
+// converge picks the best of the task's worktrees, records it as the task's
+// winner in tasks and, with merge set, accepts it. It returns "" if no
+// winner could be chosen.
+func (c *converger) converge(task Task, worktrees []WorktreeInfo, tasks []Task) string {
+	c.logf("  %s %s", highlightStyle.Render("[analyzing]"), truncate(task.Prompt, 50))
+	c.logf("    %s %s", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
+	c.logf("    %s %d worktrees", subtitleStyle.Render("Comparing:"), len(worktrees))
+	start := time.Now()
+
+	// Command-form criteria give the AI objective results to weigh
+	checks := runCriteriaChecks(task, worktrees)
+	if len(checks) > 0 {
+		for _, wt := range worktrees {
+			c.logf("    %s %s %d/%d passed", subtitleStyle.Render("[checks]"), wt.Name, checksPassed(checks[wt.Name]), len(checks[wt.Name]))
+		}
+	}
+
+	// Build the converge prompt
+	convergePrompt := buildConvergePrompt(task, worktrees, c.gitRoot, c.notes, checks)
+
+	// Reuse the analysis of an identical comparison unless --refresh
+	cacheKey := convergeCacheKey(convergePrompt, worktrees)
+	output, cached := []byte(nil), false
+	if !c.refresh {
+		output, cached = loadConvergeCache(c.autom8Path, cacheKey)
+	}
+	if cached {
+		c.logf("    %s reusing the analysis of unchanged worktrees (--refresh to re-run)", subtitleStyle.Render("[cached]"))
+	}
+
+	if !cached {
+		if err := requireAgent(); err != nil {
+			c.logf("    %s %v", errorStyle.Render("[error]"), err)
+			return ""
+		}
+	}
+
+	// Run claude to analyze, asking again while the response names no winner
+	var attempts []string
+	prompt := convergePrompt
+	for attempt := 1; !cached; attempt++ {
+		claudeArgs := withMCPConfig([]string{"-p", prompt, "--output-format", "json"}, c.mcpConfig)
+		claudeCmd := exec.Command("claude", claudeArgs...)
+		claudeCmd.Dir = c.gitRoot
+
+		var err error
+		output, err = claudeCmd.Output()
+		if err != nil {
+			c.logf("    %s failed to run AI analysis: %v", errorStyle.Render("[error]"), err)
+			return ""
+		}
+		attempts = append(attempts, string(output))
+		if winner, _ := parseConvergeResponse(string(output), worktrees); winner != "" || attempt > c.aiRetries {
+			break
+		}
+		c.logf("    %s attempt %d/%d named no valid winner, asking again", statusPendingStyle.Render("[retry]"), attempt, c.aiRetries+1)
+		c.logf("    %s", subtitleStyle.Render("AI response:"))
+		c.logf("    %s", string(output))
+		prompt = convergePrompt + "\n\n" + convergeRetryReminder
+	}
+
+	if c.explain {
+		explanation := string(output)
+		if len(attempts) > 1 {
+			// Keep the rejected responses too, they show why the retries happened
+			var b strings.Builder
+			for i, response := range attempts {
+				fmt.Fprintf(&b, "=== Attempt %d/%d ===\n%s\n\n", i+1, len(attempts), strings.TrimSpace(response))
+			}
+			explanation = b.String()
+		}
+		if path, err := saveConvergeExplanation(c.autom8Path, task.ID, explanation); err != nil {
+			c.logf("    %s could not save explanation: %v", errorStyle.Render("Warning:"), err)
+		} else {
+			fmt.Fprintf(os.Stderr, "    Explanation saved to %s\n", path)
+		}
+	}
+
+	// Parse the response to extract the winner and scores
+	winner, scores := parseConvergeResponse(string(output), worktrees)
+	if winner == "" {
+		if len(attempts) > 1 {
+			c.logf("    %s could not determine a winner after %d attempts", errorStyle.Render("[error]"), len(attempts))
+		} else {
+			c.logf("    %s could not determine a winner", errorStyle.Render("[error]"))
+		}
+		// Print the raw output for debugging
+		c.logf("    %s", subtitleStyle.Render("AI response:"))
+		c.logf("    %s", string(output))
+		return ""
+	}
+
+	// A winner that fails checks another worktree passes loses to it
+	ranked := rankByChecks(rankByScore(scores), worktrees, checks)
+	if best := ranked[0]; len(checks) > 0 && checksPassed(checks[best]) > checksPassed(checks[winner]) {
+		c.logf("    %s %s passed %d/%d checks; %s passed %d", statusPendingStyle.Render("[penalized]"), winner,
+			checksPassed(checks[winner]), len(checks[winner]), best, checksPassed(checks[best]))
+		winner = best
+	}
+
+	c.logf("    %s %s", successStyle.Render("[winner]"), highlightStyle.Render(winner))
+	if len(scores) > 0 || len(checks) > 0 {
+		c.logf("    %s", subtitleStyle.Render("Ranking:"))
+		for i, name := range ranked {
+			var detail []string
+			if score, ok := scores[name]; ok {
+				detail = append(detail, fmt.Sprintf("%d/10", score))
+			}
+			if len(checks) > 0 {
+				detail = append(detail, fmt.Sprintf("checks %d/%d", checksPassed(checks[name]), len(checks[name])))
+			}
+			c.logf("      %d. %s %s", i+1, name, subtitleStyle.Render(strings.Join(detail, ", ")))
+		}
+	}
+	if !cached {
+		if err := saveConvergeCache(c.autom8Path, cacheKey, output); err != nil {
+			c.logf("    %s could not cache the analysis: %v", errorStyle.Render("Warning:"), err)
+		}
+	}
+
+	// Update task with winner
+	for i, t := range tasks {
+		if t.ID == task.ID {
+			tasks[i].Winner = winner
+			tasks[i].WorktreeScores = scores
+			break
+		}
+	}
+
+	c.issues.add(task, fmt.Sprintf("autom8 converge selected `%s` as the best of %d implementations.", winner, len(worktrees)))
+	convergeEvent := notification{Event: "converge_winner", Task: task, Worktree: winner, Duration: time.Since(start)}
+	c.notify.send(convergeEvent)
+	if err := runHook(convergeEvent, winner); err != nil {
+		c.logf("    %s %v", errorStyle.Render("Warning:"), err)
+	}
+
+	// Auto-merge if flag is set
+	if c.merge {
+		c.logf("    %s", subtitleStyle.Render("Auto-merging winner..."))
+		// Simulate calling accept
+		if err := doAccept(winner, c.gitRoot, c.autom8Path, tasks); err != nil {
+			c.logf("    %s merge failed: %v", errorStyle.Render("[error]"), err)
+		} else {
+			c.logf("    %s merged successfully", successStyle.Render("[merged]"))
+			acceptEvent := notification{Event: "accept_merged", Task: task, Worktree: winner, Duration: time.Since(task.CreatedAt)}
+			c.notify.send(acceptEvent)
+			task.Status = "completed"
+			sendCompletionWebhook(task, winner)
+			if err := runHook(acceptEvent, headCommit(c.gitRoot)); err != nil {
+				c.logf("    %s %v", errorStyle.Render("Warning:"), err)
+			}
+			c.issues.close(task, fmt.Sprintf("Merged `%s` in %s (autom8 converge --merge).", winner, headCommit(c.gitRoot)))
+		}
+	}
+
+	return winner
+}
+
+// topWorktreesByCommits returns up to n worktrees with the most commits ahead
+// of the default branch, dropping worktrees with no commits.
+func topWorktreesByCommits(worktrees []WorktreeInfo, n int) []WorktreeInfo {
+	var candidates []WorktreeInfo
+	ahead := make(map[string]int)
+	for _, wt := range worktrees {
+		count, _ := strconv.Atoi(wt.CommitsAhead)
+		if count > 0 {
+			ahead[wt.Name] = count
+			candidates = append(candidates, wt)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return ahead[candidates[i].Name] > ahead[candidates[j].Name]
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+// waitForAgents polls until no worktree of the given tasks has a running
+// agent, and returns the refreshed worktree info.
+func waitForAgents(tasks []Task, worktreesByTask map[string][]WorktreeInfo) map[string][]WorktreeInfo {
+	tty := isTerminal(os.Stdout)
+	lastRunning := -1
+	for {
+		running := 0
+		for _, task := range tasks {
+			for _, wt := range worktreesByTask[task.ID] {
+				if wt.IsRunning {
+					running++
+				}
+			}
+		}
+
+		if running == 0 {
+			if lastRunning > 0 {
+				if tty {
+					fmt.Print("\r\033[K")
+				}
+				fmt.Println(successStyle.Render("All agents finished."))
+				fmt.Println()
+			}
+			return worktreesByTask
+		}
+
+		if tty {
+			fmt.Printf("\r\033[K%s %d agent(s) still running, checking again in %s...", statusInProgressStyle.Render("[wait]"), running, pollInterval)
+		} else if running != lastRunning {
+			fmt.Printf("%s %d agent(s) still running...\n", statusInProgressStyle.Render("[wait]"), running)
+		}
+		lastRunning = running
+
+		time.Sleep(pollInterval)
+		worktreesByTask = loadWorktreesByTask()
+	}
+}
+
+func buildConvergePrompt(task Task, worktrees []WorktreeInfo, gitRoot string, notes map[string]worktreeStats, checks map[string][]criterionCheck) string {
+	var sb strings.Builder
+	base := defaultBranch(gitRoot)
+
+	sb.WriteString("You are evaluating multiple implementations of the same task to determine which is best.\n\n")
+
+	sb.WriteString("## Task\n\n")
+	sb.WriteString(task.Prompt)
+	sb.WriteString("\n\n")
+
+	if len(task.VerificationCriteria) > 0 {
+		sb.WriteString("## Verification Criteria\n\n")
+		for _, c := range task.VerificationCriteria {
+			sb.WriteString(fmt.Sprintf("- %s\n", c))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Implementations\n\n")
+	sb.WriteString("Below are the diffs for each implementation worktree:\n\n")
+
+	for _, wt := range worktrees {
+		sb.WriteString(fmt.Sprintf("### Worktree: %s\n\n", wt.Name))
+		if wtNotes := notes[wt.Name].Notes; len(wtNotes) > 0 {
+			sb.WriteString("Notes from a human reviewer (hints to weigh, not requirements):\n")
+			for _, n := range wtNotes {
+				sb.WriteString(fmt.Sprintf("- %s\n", n.Text))
+			}
+			sb.WriteString("\n")
+		}
+		if wtChecks := checks[wt.Name]; len(wtChecks) > 0 {
+			sb.WriteString(fmt.Sprintf("Executable criteria, run in this worktree: %d/%d passed\n", checksPassed(wtChecks), len(wtChecks)))
+			for _, check := range wtChecks {
+				result := "FAIL"
+				if check.Passed {
+					result = "PASS"
+				} else if check.TimedOut {
+					result = "FAIL (timed out)"
+				}
+				sb.WriteString(fmt.Sprintf("- %s `%s`\n", result, check.Command))
+			}
+			sb.WriteString("\n")
+		}
+
+		// Get the diff for this worktree
+		diffCmd := exec.Command("git", "-C", wt.Path, "diff", fmt.Sprintf("-U%d", diffContext), base+"...HEAD")
+		diffOutput, err := diffCmd.Output()
+		if err != nil {
+			sb.WriteString("(could not get diff)\n\n")
+		} else if len(diffOutput) == 0 {
+			sb.WriteString(fmt.Sprintf("(no changes from %s)\n\n", base))
+		} else {
+			// Truncate very large diffs
+			diff := string(diffOutput)
+			if len(diff) > 50000 {
+				diff = diff[:50000] + "\n... (truncated)"
+			}
+			sb.WriteString("```diff\n")
+			sb.WriteString(diff)
+			sb.WriteString("\n```\n\n")
+		}
+	}
+
+	sb.WriteString("## Your Task\n\n")
+	sb.WriteString("Analyze each implementation and determine which one best satisfies the task requirements and verification criteria.\n\n")
+	sb.WriteString("Consider:\n")
+	sb.WriteString("- Correctness: Does the implementation actually solve the task?\n")
+	sb.WriteString("- Completeness: Are all verification criteria met?\n")
+	sb.WriteString("- Code quality: Is the code clean, readable, and maintainable?\n")
+	sb.WriteString("- Simplicity: Is the solution appropriately simple without over-engineering?\n\n")
+	if len(checks) > 0 {
+		sb.WriteString("The executable criteria results are objective: an implementation that fails a check another one passes should not win.\n\n")
+	}
+	sb.WriteString("IMPORTANT: Your response MUST include the exact worktree name of the winner in this format:\n")
+	sb.WriteString("WINNER: <worktree-name>\n\n")
+	sb.WriteString("For example: WINNER: task-123456789-1\n\n")
+	sb.WriteString("Also score every worktree from 1 (unusable) to 10 (ideal), one line each:\n")
+	sb.WriteString("SCORE: <worktree-name> <1-10>\n\n")
+	sb.WriteString("Explain your reasoning before declaring the winner.\n")
+
+	return sb.String()
+}
+
+// unwrapClaudeResult returns the result text of a claude --output-format json
+// response, or the response itself if it isn't JSON.
+func unwrapClaudeResult(response string) string {
+	var jsonResp struct {
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(response), &jsonResp); err == nil {
+		return jsonResp.Result
+	}
+	return response
+}
+
+// saveConvergeExplanation writes the AI's full converge response to
+// .autom8/convergence/<task-id>-<timestamp>.txt and returns the path.
+func saveConvergeExplanation(autom8Path, taskID, response string) (string, error) {
+	dir := filepath.Join(autom8Path, "convergence")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.txt", taskID, time.Now().Format("20060102-150405")))
+	text := unwrapClaudeResult(response)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return path, os.WriteFile(path, []byte(text), 0644)
+}
+
+// convergeNotes returns the worktrees' annotate notes for the converge
+// prompt with --with-notes, or nil.
+func convergeNotes() map[string]worktreeStats {
+	if !withNotesFlag {
+		return nil
+	}
+	stats, err := loadWorktreeStats()
+	if err != nil {
+		fmt.Printf("%s could not load worktree notes: %v\n", errorStyle.Render("Warning:"), err)
+		return nil
+	}
+	return stats
+}
+
+// convergeCacheKey identifies a comparison by its prompt, which holds the
+// task and the worktree diffs, and by each worktree's HEAD, since large
+// diffs are truncated in the prompt.
+func convergeCacheKey(convergePrompt string, worktrees []WorktreeInfo) string {
+	h := sha256.New()
+	h.Write([]byte(convergePrompt))
+	for _, wt := range worktrees {
+		fmt.Fprintf(h, "\n%s %s", wt.Name, headCommit(wt.Path))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func loadConvergeCache(autom8Path, key string) ([]byte, bool) {
+	data, err := os.ReadFile(filepath.Join(autom8Path, "converge", "cache", key+".json"))
+	return data, err == nil
+}
+
+func saveConvergeCache(autom8Path, key string, response []byte) error {
+	dir := filepath.Join(autom8Path, "converge", "cache")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, key+".json"), response, 0644)
+}
+
+// scorePattern matches "SCORE: <worktree> <1-10>" lines of a converge
+// response, allowing markdown around the name and a "/10" suffix.
+var scorePattern = regexp.MustCompile("(?i)^[-*\\s]*score:\\s*[`*_]*([^\\s`*_:=]+)[`*_]*\\s*[:=-]?\\s*(\\d+)\\s*(/\\s*10)?")
+
+// convergeRetryReminder is appended to the converge prompt when asking again
+// after a response without a winner.
+const convergeRetryReminder = "Your previous response did not include a valid WINNER: line. Please conclude with exactly: WINNER: <worktree-name>"
+
+// parseConvergeResponse returns the winner named in a converge response
+// and the 1-10 score given to each worktree. Without a WINNER line, the
+// best-scored worktree wins.
+func parseConvergeResponse(response string, worktrees []WorktreeInfo) (string, map[string]int) {
+	response = unwrapClaudeResult(response)
+
+	valid := make(map[string]bool)
+	for _, wt := range worktrees {
+		valid[wt.Name] = true
+	}
+
+	// Look for "WINNER: <name>" and "SCORE: <name> <n>" patterns
+	winner := ""
+	scores := make(map[string]int)
+	lines := strings.Split(response, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if match := scorePattern.FindStringSubmatch(line); match != nil {
+			if n, err := strconv.Atoi(match[2]); err == nil && valid[match[1]] && n >= 1 && n <= 10 {
+				scores[match[1]] = n
+			}
+			continue
+		}
+		if winner == "" && strings.HasPrefix(strings.ToUpper(line), "WINNER:") {
+			name := strings.TrimSpace(line[len("WINNER:"):])
+			// Clean up any markdown formatting
+			name = strings.Trim(name, "`*_")
+			// Verify it's a valid worktree
+			if valid[name] {
+				winner = name
+			}
+		}
+	}
+	if len(scores) == 0 {
+		scores = nil
+	}
+	if winner != "" {
+		return winner, scores
+	}
+	if ranked := rankByScore(scores); len(ranked) > 0 {
+		return ranked[0], scores
+	}
+
+	// Fallback: look for any worktree name mentioned as winner
+	responseLower := strings.ToLower(response)
+	for _, wt := range worktrees {
+		// Check if this worktree is mentioned near "winner" or "best"
+		if strings.Contains(responseLower, strings.ToLower(wt.Name)) {
+			idx := strings.Index(responseLower, strings.ToLower(wt.Name))
+			// Check surrounding context for winner-like words
+			start := idx - 50
+			if start < 0 {
+				start = 0
+			}
+			end := idx + len(wt.Name) + 50
+			if end > len(responseLower) {
+				end = len(responseLower)
+			}
+			context := responseLower[start:end]
+			if strings.Contains(context, "winner") || strings.Contains(context, "best") || strings.Contains(context, "recommend") {
+				return wt.Name, scores
+			}
+		}
+	}
+
+	return "", scores
+}
+
+// criterionCheck is the result of one command-form criterion in a worktree.
+type criterionCheck struct {
+	Command  string
+	Passed   bool
+	TimedOut bool // Killed after hooks.timeout; counts as failed
+}
+
+// criteriaCommands returns the checks converge can run for a task: criteria
+// written as "$ <command>" and the task's verify command.
+func criteriaCommands(task Task) []string {
+	var commands []string
+	for _, c := range task.VerificationCriteria {
+		if command, ok := strings.CutPrefix(strings.TrimSpace(c), "$ "); ok && strings.TrimSpace(command) != "" {
+			commands = append(commands, strings.TrimSpace(command))
+		}
+	}
+	if task.VerifyCommand != "" {
+		commands = append(commands, task.VerifyCommand)
+	}
+	return commands
+}
+
+// runCriteriaChecks runs the task's command-form criteria in each worktree.
+// A check passes if it exits zero. It returns nil if the task has none.
+func runCriteriaChecks(task Task, worktrees []WorktreeInfo) map[string][]criterionCheck {
+	commands := criteriaCommands(task)
+	if len(commands) == 0 {
+		return nil
+	}
+	cfg, _ := loadConfig()
+	checks := make(map[string][]criterionCheck)
+	for _, wt := range worktrees {
+		for _, command := range commands {
+			// A check that hangs (a watch mode, a prompt) must not stall converge
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.Hooks.timeout())
+			checkCmd := hookShellCommand(ctx, command)
+			checkCmd.Dir = wt.Path
+			checkCmd.WaitDelay = agentWaitDelay
+			err := checkCmd.Run()
+			timedOut := err != nil && ctx.Err() == context.DeadlineExceeded
+			cancel()
+			checks[wt.Name] = append(checks[wt.Name], criterionCheck{Command: command, Passed: err == nil, TimedOut: timedOut})
+		}
+	}
+	return checks
+}
+
+// checksPassed counts the passed checks.
+func checksPassed(checks []criterionCheck) int {
+	passed := 0
+	for _, check := range checks {
+		if check.Passed {
+			passed++
+		}
+	}
+	return passed
+}
+
+// rankByChecks orders worktrees by how many checks they passed, keeping the
+// order of ranked (best scored first) among equals. Unscored worktrees follow
+// the scored ones. Without checks it returns ranked, or the worktrees if none
+// were scored.
+func rankByChecks(ranked []string, worktrees []WorktreeInfo, checks map[string][]criterionCheck) []string {
+	seen := make(map[string]bool)
+	for _, name := range ranked {
+		seen[name] = true
+	}
+	all := append([]string{}, ranked...)
+	for _, wt := range worktrees {
+		if !seen[wt.Name] {
+			all = append(all, wt.Name)
+		}
+	}
+	if len(checks) == 0 && len(ranked) > 0 {
+		return ranked
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return checksPassed(checks[all[i]]) > checksPassed(checks[all[j]])
+	})
+	return all
+}
+
+// rankByScore orders the scored worktrees best first, ties by name.
+func rankByScore(scores map[string]int) []string {
+	ranked := make([]string, 0, len(scores))
+	for name := range scores {
+		ranked = append(ranked, name)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if scores[ranked[i]] != scores[ranked[j]] {
+			return scores[ranked[i]] > scores[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked
+}
+
+func doAccept(worktreeName, gitRoot, autom8Path string, tasks []Task) error {
+	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+
+	// Check if worktree exists
+	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
+		return ErrWorktreeNotFound{Name: worktreeName}
+	}
+
+	// Get the branch name from the worktree
+	branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
+	branchOutput, err := branchCmd.Output()
+	if err != nil {
+		return fmt.Errorf("error getting branch name: %w", err)
+	}
+	branchName := strings.TrimSpace(string(branchOutput))
+
+	if branchName == "" {
+		return fmt.Errorf("could not determine branch name for worktree")
+	}
+
+	// Check for uncommitted changes in the worktree
+	statusCmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
+	statusOutput, err := statusCmd.Output()
+	if err != nil {
+		return fmt.Errorf("error checking worktree status: %w", err)
+	}
+
+	if len(strings.TrimSpace(string(statusOutput))) > 0 {
+		// Stage all changes
+		addCmd := exec.Command("git", "-C", worktreePath, "add", "-A")
+		if _, err := addCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("error staging changes: %w", err)
+		}
+
+		// Commit with auto-commit message
+		commitCmd := exec.Command("git", "-C", worktreePath, "commit", "-m", "autom8: auto-commit uncommitted changes")
+		if _, err := commitCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("error committing changes: %w", err)
+		}
+	}
+
+	if err := runPreAcceptHook(worktreeName, branchName); err != nil {
+		return err
+	}
+
+	// Merge the branch into the current branch. A failed merge is always
+	// aborted, so the next task of the run merges into a clean tree
+	mergeCmd := exec.Command("git", "-C", gitRoot, "merge", branchName, "-m", fmt.Sprintf("Merge %s (autom8 converge)", branchName))
+	if output, err := mergeCmd.CombinedOutput(); err != nil {
+		conflicts := conflictedFiles(gitRoot)
+		if abortErr := abortMerge(gitRoot); abortErr != nil {
+			return fmt.Errorf("error merging branch: %w\n%s%s\n%v", err, string(output), conflictGuidance(conflicts), abortErr)
+		}
+		if len(conflicts) == 0 {
+			return fmt.Errorf("error merging branch: %w\n%s", err, string(output))
+		}
+		return fmt.Errorf("merge stopped on conflicts and was aborted\n%s\nAccept it by hand with 'autom8 accept %s --keep-conflicts'",
+			conflictGuidance(conflicts), worktreeName)
+	}
+
+	// Remove the worktree
+	leaveWorktree(worktreePath, gitRoot)
+	removeCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", worktreePath)
+	if _, err := removeCmd.CombinedOutput(); err != nil {
+		// Non-fatal, continue
+	}
+
+	// Delete the branch
+	deleteBranchCmd := exec.Command("git", "-C", gitRoot, "branch", "-d", branchName)
+	deleteBranchCmd.Run()
+
+	// Mark the task as completed
+	taskID := parseTaskIDFromWorktreeName(worktreeName)
+
+	for i, t := range tasks {
+		if t.ID == taskID {
+			tasks[i].Status = "completed"
+			tasks[i].UpdatedAt = time.Now()
+			break
+		}
+	}
+
+	return nil
+}
+
+func runImplement(cmd *cobra.Command, args []string) error {
+	if epicFlag != "" && len(args) > 0 {
+		return fmt.Errorf("--epic selects pending tasks; drop it when implementing specific tasks")
+	}
+	// A bare --auto-converge takes no value, so "--auto-converge merge"
+	// would implement a task named merge
+	if cmd.Flags().Changed("auto-converge") && autoConverge == "on" {
+		for _, arg := range args {
+			if arg == "on" || arg == "merge" || arg == "off" {
+				return fmt.Errorf("'%s' was read as a task ID; use --auto-converge=%s", arg, arg)
+			}
+		}
+	}
+	outcomes, err := implementTasks(args, "")
+	if err != nil || outcomes == nil {
+		return err
+	}
+	return outcomes.checkFailures(allowFailures)
+}
+
+// runPipeline creates a task and takes it through implement, converge and
+// (with --auto-accept) accept. Each stage leaves its state behind, so a
+// failed run can be continued with the individual commands.
+func runPipeline(cmd *cobra.Command, args []string) error {
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(promptFlag) == "" {
+		return fmt.Errorf("a prompt is required (-p)")
+	}
+
+	task, err := createTask(promptFlag, criteriaFlags, "", false)
+	if err != nil {
+		return fmt.Errorf("error creating task: %w", err)
+	}
+	fmt.Printf("%s %s\n\n", successStyle.Render("Created task"), idStyle.Render(task.ID))
+
+	outcomes, err := implementTasks([]string{task.ID}, "")
+	if err != nil {
+		return fmt.Errorf("implement failed: %w\nThe task is saved; continue with 'autom8 implement %s'", err, task.ID)
+	}
+	if outcomes == nil {
+		// implementTasks returns nil outcomes when it started nothing
+		return fmt.Errorf("task '%s' was not implemented\nThe task is saved; continue with 'autom8 implement %s'", task.ID, task.ID)
+	}
+	completed := outcomes.names("worktree_completed")
+	if len(completed) == 0 {
+		return fmt.Errorf("no worktree of task '%s' completed (%d failed, %d stopped)\nThe task and worktrees are kept; inspect them with 'autom8 status'",
+			task.ID, outcomes.get("worktree_failed"), outcomes.get("worktree_stopped"))
+	}
+	fmt.Println()
+
+	winner := completed[0]
+	if len(completed) > 1 {
+		if err := runConverge(cmd, []string{task.ID}); err != nil {
+			return fmt.Errorf("converge failed: %w", err)
+		}
+		tasks, err := loadTasks()
+		if err != nil {
+			return fmt.Errorf("error loading tasks: %w", err)
+		}
+		winner = ""
+		if t := findTask(tasks, task.ID); t != nil {
+			winner = t.Winner
+		}
+		if winner == "" {
+			return fmt.Errorf("converge did not pick a winner\nCompare the worktrees with 'autom8 status' and accept one with 'autom8 accept <worktree>'")
+		}
+		isCompleted := false
+		for _, name := range completed {
+			if name == winner {
+				isCompleted = true
+				break
+			}
+		}
+		if !isCompleted {
+			return fmt.Errorf("converge picked '%s', which did not complete\nReview the worktrees and accept one with 'autom8 accept <worktree>'", winner)
+		}
+		fmt.Println()
+	}
+
+	mergeCommit := ""
+	if autoAcceptFlag {
+		if err := runAccept(cmd, []string{winner}); err != nil {
+			return fmt.Errorf("accept failed: %w\nThe worktree is kept; resolve the problem and run 'autom8 accept %s'", err, winner)
+		}
+		mergeCommit = headCommit(gitRoot)
+		fmt.Println()
+	}
+
+	fmt.Println(titleStyle.Render("Run Summary"))
+	fmt.Println()
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Task:"), idStyle.Render(task.ID))
+	fmt.Printf("  %s %d completed, %d failed, %d stopped\n", subtitleStyle.Render("Worktrees:"),
+		len(completed), outcomes.get("worktree_failed"), outcomes.get("worktree_stopped"))
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Winner:"), highlightStyle.Render(winner))
+	if mergeCommit != "" {
+		fmt.Printf("  %s %s\n", subtitleStyle.Render("Merge commit:"), highlightStyle.Render(mergeCommit))
+	} else {
+		fmt.Printf("  %s autom8 accept %s\n", subtitleStyle.Render("Next:"), winner)
+	}
+	return nil
+}
+
+// implementTasks implements the given tasks, or every pending task if none
+// are given, and returns the outcome of every worktree (nil if nothing was
+// started). Tasks without a parent worktree to branch from start from base,
+// or HEAD if base is empty.
+func implementTasks(args []string, base string) (*outcomeCounts, error) {
+	if numInstances < 1 {
+		numInstances = 1
+	}
+
+	if labelFlag != "" && !branchLabelPattern.MatchString(labelFlag) {
+		return nil, fmt.Errorf("invalid label '%s': use only letters, digits, '.', '_' and '-'", labelFlag)
+	}
+
+	switch parentStrategy {
+	case "exponential", "winner", "first":
+	default:
+		return nil, fmt.Errorf("invalid --parent-strategy '%s': use exponential, winner or first", parentStrategy)
+	}
+	agentArgs, err := parseAgentArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := newResultWriter(implementOutput)
+	if err != nil {
+		return nil, err
+	}
+	if jsonEvents && results.format != "plain" {
+		return nil, fmt.Errorf("--json-events already reports each result; drop --output-format")
+	}
+	var events *eventStream
+	if jsonEvents {
+		events = newEventStream(os.Stdout)
+		defer events.close()
+	}
+	if results.format == "json" || jsonEvents {
+		// stdout carries the JSON results only; everything else goes to stderr
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+		defer func() { os.Stdout = stdout }()
+	}
+
+	if stdinPrompt {
+		if err := requireAgent(); err != nil {
+			return nil, err
+		}
+		return nil, runImplementStdinPrompt(args, agentArgs, results, events)
+	}
+
+	// Check if specific task IDs were provided
+	targetIDs := make(map[string]bool)
+	for _, id := range args {
+		targetIDs[id] = true
+	}
+	if implementLimit < 0 {
+		return nil, fmt.Errorf("invalid --limit %d: use a positive number", implementLimit)
+	}
+	if implementLimit > 0 && len(targetIDs) > 0 {
+		return nil, fmt.Errorf("--limit applies to pending tasks; drop it when implementing a single task")
+	}
+	fireAt, err := scheduleTime(atFlag, afterFlag, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if noWaitFlag && fireAt.IsZero() {
+		return nil, fmt.Errorf("--no-wait needs --at or --after")
+	}
+	if autoVerifyFlag && verifyAfter != "" {
+		return nil, fmt.Errorf("--auto-verify and --verify-after cannot be combined")
+	}
+	convergeMode := autoConverge
+	if convergeMode == "" {
+		cfg, err := loadConfig()
+		if err != nil {
+			return nil, err
+		}
+		convergeMode = cfg.Implement.AutoConverge
+	}
+	switch convergeMode {
+	case "", "off":
+		convergeMode = ""
+	case "on", "merge":
+	default:
+		return nil, fmt.Errorf("invalid --auto-converge '%s': use on, merge or off", convergeMode)
+	}
+
+	tasks, err := loadTasks()
+	if err != nil {
+		return nil, fmt.Errorf("error loading tasks: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println(subtitleStyle.Render("No tasks found. Use 'autom8 new' to create one."))
+		return nil, nil
+	}
+
+	// Filter tasks to implement
+	var pendingTasks []Task
+	notYetDue, queuedElsewhere := 0, 0
+	otherRuns := tasksQueuedElsewhere()
+	for _, task := range tasks {
+		// If specific task IDs were provided, only include those tasks
+		if len(targetIDs) > 0 {
+			if targetIDs[task.ID] {
+				if task.Status == "completed" {
+					return nil, fmt.Errorf("task '%s' is already completed", task.ID)
+				}
+				pendingTasks = append(pendingTasks, task)
+			}
+		} else if epicFlag != "" && task.Epic != epicFlag {
+			continue
+		} else if task.Status == "pending" && fireAt.IsZero() && task.scheduledAfter(time.Now()) {
+			// Scheduled earlier; implement it by ID to start it now
+			notYetDue++
+		} else if task.Status == "pending" && otherRuns[task.ID] {
+			// Planned by another run that hasn't created its worktrees yet
+			queuedElsewhere++
+		} else if task.Status == "pending" {
+			pendingTasks = append(pendingTasks, task)
+		}
+	}
+
+	if len(pendingTasks) < len(targetIDs) {
+		for _, id := range args {
+			if findTask(pendingTasks, id) == nil {
+				return nil, ErrTaskNotFound{ID: id}
+			}
+		}
+	}
+
+	if notYetDue > 0 {
+		fmt.Printf("%s %d scheduled task(s) not due yet; see 'autom8 queue'\n", subtitleStyle.Render("Skipping:"), notYetDue)
+	}
+	if queuedElsewhere > 0 {
+		fmt.Printf("%s %d task(s) queued by another autom8 run; see 'autom8 queue'\n", subtitleStyle.Render("Skipping:"), queuedElsewhere)
+	}
+	if len(pendingTasks) == 0 && epicFlag != "" {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("No pending tasks in epic '%s' to implement.", epicFlag)))
+		return nil, nil
+	}
+	if len(pendingTasks) == 0 {
+		fmt.Println(subtitleStyle.Render("No pending tasks to implement."))
+		return nil, nil
+	}
+
+	// Checked only now that there is something to run, and before any wait
+	// for a schedule, which could otherwise end in this error hours later
+	if !noWaitFlag {
+		if err := requireAgent(); err != nil {
+			return nil, err
+		}
+	}
+
+	leftPending := 0
+	if implementLimit > 0 {
+		limited := limitPendingTasks(pendingTasks, implementLimit)
+		leftPending = len(pendingTasks) - len(limited)
+		pendingTasks = limited
+	}
+
+	if !fireAt.IsZero() {
+		if err := scheduleTasks(pendingTasks, fireAt); err != nil {
+			return nil, fmt.Errorf("error scheduling tasks: %w", err)
+		}
+		fmt.Printf("%s %d task(s) for %s\n", successStyle.Render("Scheduled"), len(pendingTasks), fireAt.Format("Mon Jan 2 15:04"))
+		if noWaitFlag {
+			fmt.Println(subtitleStyle.Render("A running 'autom8 watch' starts them then; cancel with 'autom8 queue cancel <task-id>'."))
+			return nil, nil
+		}
+		pendingTasks, err = waitForSchedule(pendingTasks, fireAt)
+		if err != nil {
+			return nil, err
+		}
+		if len(pendingTasks) == 0 {
+			fmt.Println(subtitleStyle.Render("Every scheduled task was cancelled; nothing to implement."))
+			return nil, nil
+		}
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	mcpConfig, err := loadMCPConfig(gitRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	autom8Path, err := ensureAutom8Dir()
+	if err != nil {
+		return nil, fmt.Errorf("error ensuring autom8 dir: %w", err)
+	}
+
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	if err := os.MkdirAll(worktreesDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating worktrees dir: %w", err)
+	}
+
+	// Build task map for dependency lookup
+	taskMap := make(map[string]Task)
+	for _, t := range tasks {
+		taskMap[t.ID] = t
+	}
+
+	// Separate tasks with and without dependencies. A completed parent is
+	// already merged, so its dependents start from the base branch like
+	// independent tasks.
+	var independentTasks []Task
+	var dependentTasks []Task
+	for _, task := range pendingTasks {
+		if task.DependsOn == "" || taskMap[task.DependsOn].Status == "completed" {
+			independentTasks = append(independentTasks, task)
+		} else {
+			dependentTasks = append(dependentTasks, task)
+		}
+	}
+
+	// With --parent-strategy winner, dependents branch from their parent's
+	// converge winner, which must exist before anything starts
+	winnerBases := make(map[string]string)
+	if parentStrategy == "winner" {
+		for _, task := range dependentTasks {
+			base, err := winnerBaseBranchID(gitRoot, taskMap[task.DependsOn])
+			if err != nil {
+				return nil, fmt.Errorf("cannot branch '%s' from its parent's winner: %w", task.ID, err)
+			}
+			winnerBases[task.ID] = base
+		}
+	}
+
+	// Calculate total instances (exponential for dependencies by default;
+	// a dependent of a dependent multiplies again)
+	totalIndependent := len(independentTasks) * numInstances
+	totalDependent := len(dependentTasks) * numInstances
+	if parentStrategy == "exponential" {
+		totalDependent = 0
+		planned := make(map[string]int)
+		for _, task := range orderByDependency(dependentTasks) {
+			parentInstances := planned[task.DependsOn]
+			if parentInstances == 0 {
+				parentInstances = numInstances
+			}
+			planned[task.ID] = parentInstances * numInstances
+			totalDependent += planned[task.ID]
+		}
+	}
+
+	fmt.Println(titleStyle.Render("Starting Implementation"))
+	fmt.Println()
+	fmt.Printf("  %s %d\n", subtitleStyle.Render("Instances per task:"), numInstances)
+	fmt.Printf("  %s %d task(s) x %d = %d worktrees\n",
+		subtitleStyle.Render("Independent:"), len(independentTasks), numInstances, totalIndependent)
+	if len(dependentTasks) > 0 && parentStrategy == "exponential" {
+		fmt.Printf("  %s %d task(s), %d per parent instance = %d worktrees (exponential)\n",
+			subtitleStyle.Render("Dependent:"), len(dependentTasks), numInstances, totalDependent)
+	} else if len(dependentTasks) > 0 {
+		source := "winner"
+		if parentStrategy == "first" {
+			source = "first instance"
+		}
+		fmt.Printf("  %s %d task(s) x %d = %d worktrees (from the parent's %s)\n",
+			subtitleStyle.Render("Dependent:"), len(dependentTasks), numInstances, totalDependent, source)
+	}
+	if leftPending > 0 {
+		fmt.Printf("  %s %d task(s) left pending by --limit\n", subtitleStyle.Render("Skipped:"), leftPending)
+	}
+	fmt.Println()
+
+	// Tasks move to in-progress as their first worktree is created, see
+	// markTaskStarted, so a failing 'git worktree add' leaves them pending
+	issues := newIssueSync(gitRoot)
+	for _, t := range pendingTasks {
+		issues.add(t, fmt.Sprintf("autom8 started implementing this issue as task `%s` (%d instance(s)).", t.ID, numInstances))
+	}
+	issues.flush()
+
+	// Load the implementer agent template
+	agentTemplate, err := loadImplementerTemplate()
+	if err != nil {
+		// Template is optional, continue without it
+		agentTemplate = ""
+	}
+	warnLargePrompts(pendingTasks, agentTemplate, strings.TrimSpace(promptAppend))
+
+	ctx, abort := context.WithCancelCause(context.Background())
+	defer abort(nil)
+
+	opts := implementOptions{
+		ctx:           ctx,
+		abort:         abort,
+		gitRoot:       gitRoot,
+		worktreesDir:  worktreesDir,
+		label:         labelFlag,
+		agentTemplate: agentTemplate,
+		mcpConfig:     mcpConfig,
+		promptAppend:  strings.TrimSpace(promptAppend),
+		verifyAfter:   verifyAfter,
+		autoVerify:    autoVerifyFlag,
+		base:          base,
+		maxIter:       maxIterations,
+		backoff:       rateLimitBackoff,
+		maxRetries:    maxRetries,
+		agentArgs:     agentArgs,
+		logCap:        worktreeLogCap(),
+	}
+
+	// Plan every worktree up front so progress can be shown for all of them
+	var jobs []implementJob
+
+	// Instance suffixes of every task planned in this run, so dependents
+	// (and their dependents) branch from instances that will exist
+	plannedSuffixes := make(map[string][]string)
+
+	// Independent tasks branch from main
+	for _, task := range independentTasks {
+		plannedSuffixes[task.ID] = make([]string, numInstances)
+		for i := 0; i < numInstances; i++ {
+			suffix := fmt.Sprintf("-%d", i+1)
+			plannedSuffixes[task.ID][i] = suffix
+			jobs = append(jobs, implementJob{task: task, suffix: suffix})
+		}
+	}
+
+	// Dependent tasks branch from each instance of their parent, parents first
+	for _, task := range orderByDependency(dependentTasks) {
+		depSuffixes := plannedSuffixes[task.DependsOn]
+		if depSuffixes == nil {
+			depSuffixes = make([]string, numInstances)
+			for i := 0; i < numInstances; i++ {
+				depSuffixes[i] = fmt.Sprintf("-%d", i+1)
+			}
+		}
+
+		// Parents started in this run carry the same label
+		parentLabel := ""
+		if _, ok := plannedSuffixes[task.DependsOn]; ok {
+			parentLabel = labelFlag
+		}
+
+		// winner and first branch every instance from a single parent worktree
+		if parentStrategy != "exponential" {
+			baseBranchID := winnerBases[task.ID]
+			if parentStrategy == "first" {
+				baseBranchID = worktreeInstanceID(parentLabel, task.DependsOn, depSuffixes[0])
+			}
+			for i := 0; i < numInstances; i++ {
+				suffix := fmt.Sprintf("-%d", i+1)
+				plannedSuffixes[task.ID] = append(plannedSuffixes[task.ID], suffix)
+				jobs = append(jobs, implementJob{task: task, baseBranchID: baseBranchID, suffix: suffix})
+			}
+			continue
+		}
+
+		for _, depSuffix := range depSuffixes {
+			for i := 0; i < numInstances; i++ {
+				suffix := fmt.Sprintf("%s-%d", depSuffix, i+1)
+				plannedSuffixes[task.ID] = append(plannedSuffixes[task.ID], suffix)
+				jobs = append(jobs, implementJob{
+					task:         task,
+					baseBranchID: worktreeInstanceID(parentLabel, task.DependsOn, depSuffix),
+					suffix:       suffix,
+				})
+			}
+		}
+	}
+
+	names := make([]string, len(jobs))
+	taskIDs := make([]string, len(jobs))
+	for i, job := range jobs {
+		names[i] = worktreeInstanceID(opts.label, job.task.ID, job.suffix)
+		taskIDs[i] = job.task.ID
+	}
+	if err := enqueueWorktrees(names, taskIDs); err != nil {
+		return nil, fmt.Errorf("error queueing worktrees: %w", err)
+	}
+	opts.progress = newProgressDisplay(names)
+	opts.notifier = newNotifier(opts.progress)
+	opts.budget = newRunBudget(budgetUSD, budgetTime)
+	opts.outcomes = newOutcomeCounts()
+	opts.events = events
+
+	// With --auto-converge, a task is converged once the last of its
+	// worktrees in this run finishes, comparing the ones that completed
+	var conv *converger
+	jobsLeft := make(map[string]int)
+	runWorktrees := make(map[string][]string)
+	hasDependents := make(map[string]bool)
+	if convergeMode != "" {
+		conv = &converger{
+			gitRoot:    gitRoot,
+			autom8Path: filepath.Dir(worktreesDir),
+			mcpConfig:  mcpConfig,
+			aiRetries:  1,
+			merge:      convergeMode == "merge",
+			issues:     newIssueSync(gitRoot),
+			notify:     opts.notifier,
+			logf:       func(format string, args ...any) { opts.progress.println(fmt.Sprintf(format, args...)) },
+		}
+		for i, job := range jobs {
+			jobsLeft[job.task.ID]++
+			runWorktrees[job.task.ID] = append(runWorktrees[job.task.ID], names[i])
+			if job.task.DependsOn != "" {
+				hasDependents[job.task.DependsOn] = true
+			}
+		}
+	}
+
+	// A dependent instance starts once the parent instance it branches from
+	// has finished in this run, and is skipped if that one did not complete
+	gates := make(map[string]*instanceGate)
+	for _, name := range names {
+		gates[name] = &instanceGate{done: make(chan struct{})}
+	}
+
+	var wg sync.WaitGroup
+	finished := make(chan implementResult, len(jobs))
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(j implementJob, name string) {
+			defer wg.Done()
+			gate := gates[name]
+			defer close(gate.done)
+
+			var r implementResult
+			if parent, ok := gates[j.baseBranchID]; ok {
+				opts.progress.update(name, "waiting for "+j.baseBranchID)
+				opts.events.send(implementEvent{Type: "waiting", Task: j.task.ID, Worktree: name, State: "waiting for " + j.baseBranchID})
+				<-parent.done
+				if parent.status != "completed" && parent.status != "skipped" {
+					dequeueWorktrees(name)
+					opts.outcomes.add("worktree_skipped", name)
+					r.parentFailed = true
+					r.result = worktreeResult{Worktree: name}.with("skipped", "parent %s %s", j.baseBranchID, parent.status)
+				}
+			} else if j.baseBranchID != "" && exec.Command("git", "-C", gitRoot, "rev-parse", "--verify", "--quiet", "refs/heads/autom8/"+j.baseBranchID).Run() != nil {
+				// Parent from an earlier run that never produced this instance
+				dequeueWorktrees(name)
+				opts.outcomes.add("worktree_skipped", name)
+				r.parentFailed = true
+				r.result = worktreeResult{Worktree: name}.with("skipped", "parent branch autom8/%s does not exist", j.baseBranchID)
+			}
+			if !r.parentFailed {
+				r.result = implementTaskWithSuffix(j.task, opts, j.baseBranchID, j.suffix)
+			} else {
+				opts.events.send(implementEvent{Type: "finished", Task: j.task.ID, Worktree: name, State: r.result.Status, Result: &r.result})
+			}
+			// Instances skipped for their parent count as not produced, so
+			// their own dependents are skipped too
+			gate.status = r.result.Status
+			if r.parentFailed {
+				gate.status = "skipped for its parent"
+			}
+			opts.progress.finish(name)
+			r.job = j
+			finished <- r
+		}(job, names[i])
+	}
+
+	// Wait and collect results
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	var merged []string
+	parentSkips := make(map[string]int)
+	createFailures := make(map[string]int)
+	for result := range finished {
+		results.add(result.result, opts.progress)
+		taskID := result.job.task.ID
+		if result.parentFailed {
+			parentSkips[taskID]++
+		}
+		if result.result.Class == failGit {
+			createFailures[taskID]++
+		}
+		jobsLeft[taskID]--
+		if conv != nil && jobsLeft[taskID] == 0 && len(runWorktrees[taskID]) > 1 {
+			if autoConvergeTask(conv, result.job.task, runWorktrees[taskID], hasDependents[taskID], opts) {
+				merged = append(merged, taskID)
+			}
+		}
+	}
+	if conv != nil {
+		conv.issues.flush()
+	}
+	results.flush()
+	resetParentSkippedTasks(jobs, parentSkips)
+	resetUncreatedTasks(jobs, createFailures, worktreesDir)
+
+	if opts.budget != nil {
+		fmt.Println()
+		fmt.Printf("%s %s; %d worktree(s) completed within budget, %d stopped\n", subtitleStyle.Render("Budget:"), opts.budget.summary(),
+			opts.outcomes.get("worktree_completed"), opts.outcomes.get("worktree_stopped"))
+	}
+
+	opts.notifier.finish("autom8 implement finished", fmt.Sprintf("%d completed, %d failed, %d stopped",
+		opts.outcomes.get("worktree_completed"), opts.outcomes.get("worktree_failed"), opts.outcomes.get("worktree_stopped")))
+
+	fmt.Println()
+	opts.outcomes.printSummary()
+	fmt.Println()
+	fmt.Println(subtitleStyle.Render("Use 'autom8 status' to see results."))
+
+	if err := context.Cause(ctx); err != nil {
+		return opts.outcomes, fmt.Errorf("run aborted: %w", err)
+	}
+	startDependents(merged, "")
+	return opts.outcomes, nil
+}
+
+// scheduleTime turns --at or --after into the time implement should start,
+// or the zero time if neither is set. --at with only a time of day means
+// its next occurrence.
+func scheduleTime(at string, after time.Duration, now time.Time) (time.Time, error) {
+	switch {
+	case at != "" && after != 0:
+		return time.Time{}, fmt.Errorf("use either --at or --after, not both")
+	case after < 0:
+		return time.Time{}, fmt.Errorf("--after must be positive")
+	case after > 0:
+		return now.Add(after), nil
+	case at == "":
+		return time.Time{}, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02 15:04", at, time.Local); err == nil {
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("--at %s is in the past", at)
+		}
+		return t, nil
+	}
+	clock, err := time.ParseInLocation("15:04", at, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --at '%s': use 15:04 or 2006-01-02 15:04", at)
+	}
+	t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local)
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// scheduleTasks records fireAt as the tasks' NotBefore, so watch honors the
+// schedule too.
+func scheduleTasks(scheduled []Task, fireAt time.Time) error {
+	ids := make(map[string]bool)
+	for _, t := range scheduled {
+		ids[t.ID] = true
+	}
+	return updateTasks(func(tasks []Task) ([]Task, error) {
+		for i, t := range tasks {
+			if ids[t.ID] {
+				tasks[i].NotBefore = fireAt
+				tasks[i].UpdatedAt = time.Now()
+			}
+		}
+		return tasks, nil
+	})
+}
+
+// waitForSchedule blocks until fireAt and returns the tasks that are still
+// scheduled for it: 'autom8 queue cancel <task-id>' clears a schedule, and a
+// task started by another command is no longer pending.
+func waitForSchedule(scheduled []Task, fireAt time.Time) ([]Task, error) {
+	fmt.Println(subtitleStyle.Render(fmt.Sprintf("Waiting until %s (Ctrl+C keeps the schedule for 'autom8 watch')...", fireAt.Format("15:04"))))
+	for {
+		wait := time.Until(fireAt)
+		if wait > 5*time.Second {
+			wait = 5 * time.Second
+		}
+		time.Sleep(max(wait, 0))
+
+		tasks, err := loadTasks()
+		if err != nil {
+			return nil, fmt.Errorf("error loading tasks: %w", err)
+		}
+		var still []Task
+		for _, s := range scheduled {
+			if t := findTask(tasks, s.ID); t != nil && t.Status == "pending" && t.NotBefore.Equal(fireAt) {
+				still = append(still, *t)
+			}
+		}
+		if len(still) == 0 || !time.Now().Before(fireAt) {
+			if dropped := len(scheduled) - len(still); dropped > 0 {
+				fmt.Printf("%s %d task(s) were cancelled or started elsewhere\n", subtitleStyle.Render("Dropped:"), dropped)
+			}
+			return still, nil
+		}
+		scheduled = still
+	}
+}
+
+// limitPendingTasks picks the first n tasks by creation time. A dependent
+// whose parent is also pending is only picked along with its parent, since
+// it branches from the parent's worktrees.
+func limitPendingTasks(pending []Task, n int) []Task {
+	sorted := append([]Task(nil), pending...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	isPending := make(map[string]bool)
+	for _, t := range pending {
+		isPending[t.ID] = true
+	}
+
+	picked := make(map[string]bool)
+	var limited []Task
+	for _, t := range sorted {
+		if len(limited) == n {
+			break
+		}
+		if t.DependsOn != "" && isPending[t.DependsOn] && !picked[t.DependsOn] {
+			continue
+		}
+		picked[t.ID] = true
+		limited = append(limited, t)
+	}
+	return limited
+}
+
+// runImplementStdinPrompt implements a one-off prompt read from stdin in a
+// single tmp- worktree, without adding a task to tasks.json.
+func runImplementStdinPrompt(args, agentArgs []string, results *resultWriter, events *eventStream) error {
+	if len(args) > 0 {
+		return fmt.Errorf("--stdin-prompt cannot be combined with a task ID")
+	}
+	if numInstances > 1 {
+		return fmt.Errorf("--stdin-prompt runs a single worktree; drop -n")
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("error reading prompt from stdin: %w", err)
+	}
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		return fmt.Errorf("no prompt provided on stdin")
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	mcpConfig, err := loadMCPConfig(gitRoot)
+	if err != nil {
+		return err
+	}
+
+	autom8Path, err := ensureAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error ensuring autom8 dir: %w", err)
+	}
+
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	if err := os.MkdirAll(worktreesDir, 0755); err != nil {
+		return fmt.Errorf("error creating worktrees dir: %w", err)
+	}
+
+	task := Task{
+		ID:        fmt.Sprintf("%s%d", tempTaskPrefix, time.Now().UnixNano()),
+		Prompt:    prompt,
+		CreatedAt: time.Now(),
+		Status:    "in-progress",
+	}
+	suffix := "-1"
+	instanceID := worktreeInstanceID(labelFlag, task.ID, suffix)
+
+	// Keep the task next to the worktree's logs so accept can offer to save it
+	logsDir := filepath.Join(autom8Path, "logs", instanceID)
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		return fmt.Errorf("error creating logs dir: %w", err)
+	}
+	taskData, err := json.MarshalIndent(task, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(logsDir, tempTaskFile), taskData, 0644); err != nil {
+		return fmt.Errorf("error saving temporary task: %w", err)
+	}
+
+	agentTemplate, err := loadImplementerTemplate()
+	if err != nil {
+		agentTemplate = ""
+	}
+	warnLargePrompts([]Task{task}, agentTemplate, strings.TrimSpace(promptAppend))
+
+	opts := implementOptions{
+		ctx:           context.Background(),
+		gitRoot:       gitRoot,
+		worktreesDir:  worktreesDir,
+		label:         labelFlag,
+		agentTemplate: agentTemplate,
+		mcpConfig:     mcpConfig,
+		promptAppend:  strings.TrimSpace(promptAppend),
+		verifyAfter:   verifyAfter,
+		maxIter:       maxIterations,
+		backoff:       rateLimitBackoff,
+		maxRetries:    maxRetries,
+		agentArgs:     agentArgs,
+		logCap:        worktreeLogCap(),
+	}
+
+	fmt.Println(titleStyle.Render("Starting Implementation"))
+	fmt.Println()
+	fmt.Printf("  %s %s\n", subtitleStyle.Render("Temporary task (not saved):"), truncate(prompt, 50))
+	fmt.Println()
+
+	if err := enqueueWorktrees([]string{instanceID}, []string{task.ID}); err != nil {
+		return fmt.Errorf("error queueing worktree: %w", err)
+	}
+	opts.progress = newProgressDisplay([]string{instanceID})
+	opts.notifier = newNotifier(opts.progress)
+	opts.budget = newRunBudget(budgetUSD, budgetTime)
+	opts.outcomes = newOutcomeCounts()
+	opts.events = events
+
+	result := implementTaskWithSuffix(task, opts, "", suffix)
+	opts.progress.finish(instanceID)
+	results.add(result, opts.progress)
+	results.flush()
+
+	if opts.budget != nil {
+		fmt.Println()
+		fmt.Printf("%s %s\n", subtitleStyle.Render("Budget:"), opts.budget.summary())
+	}
+
+	opts.notifier.finish("autom8 implement finished", fmt.Sprintf("%d completed, %d failed, %d stopped",
+		opts.outcomes.get("worktree_completed"), opts.outcomes.get("worktree_failed"), opts.outcomes.get("worktree_stopped")))
+
+	if err := opts.outcomes.checkFailures(allowFailures); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(subtitleStyle.Render(fmt.Sprintf("Use 'autom8 accept %s' to merge it; you'll be asked whether to save the task.", instanceID)))
+	return nil
+}
+
+// watchDebounce is how long tasks.json must stay unchanged before watch rescans it
+const watchDebounce = 500 * time.Millisecond
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if onExitFlag != "kill" && onExitFlag != "detach" {
+		return fmt.Errorf("invalid --on-exit '%s': use kill or detach", onExitFlag)
+	}
+	if numInstances < 1 {
+		numInstances = 1
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		return err
+	}
+
+	mcpConfig, err := loadMCPConfig(gitRoot)
+	if err != nil {
+		return err
+	}
+
+	autom8Path, err := ensureAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error ensuring autom8 dir: %w", err)
+	}
+
+	worktreesDir := filepath.Join(autom8Path, "worktrees")
+	if err := os.MkdirAll(worktreesDir, 0755); err != nil {
+		return fmt.Errorf("error creating worktrees dir: %w", err)
+	}
+
+	agentTemplate, err := loadAgentTemplate("implementer")
+	if err != nil {
+		agentTemplate = ""
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := implementOptions{
+		ctx:           ctx,
+		gitRoot:       gitRoot,
+		worktreesDir:  worktreesDir,
+		agentTemplate: agentTemplate,
+		mcpConfig:     mcpConfig,
+		maxIter:       maxIterations,
+		backoff:       rateLimitBackoff,
+		maxRetries:    maxRetries,
+		logCap:        worktreeLogCap(),
+		outcomes:      newOutcomeCounts(),
+	}
+	if onExitFlag == "detach" {
+		// Agents are left running when watch exits, so never cancel them
+		opts.ctx = context.Background()
+		opts.detach = true
+	}
+	opts.notifier = newNotifier(nil)
+
+	parallel := "unlimited"
+	if maxParallel > 0 {
+		parallel = strconv.Itoa(maxParallel)
+	}
+	fmt.Println(titleStyle.Render("Watching for Tasks"))
+	fmt.Println()
+	fmt.Printf("  %s %d\n", subtitleStyle.Render("Instances per task:"), numInstances)
+	fmt.Printf("  %s %s worktree(s)\n", subtitleStyle.Render("Max parallel:"), parallel)
+	fmt.Printf("  %s %s running agents\n", subtitleStyle.Render("On exit:"), onExitFlag)
+	fmt.Println()
+	fmt.Println(subtitleStyle.Render("Press Ctrl+C to stop."))
+	fmt.Println()
+
+	tasksPath := filepath.Join(autom8Path, tasksFile)
+	var lastMod time.Time   // Modification time of tasks.json at the last check
+	var changedAt time.Time // When tasks.json last changed without being rescanned yet
+	if info, err := os.Stat(tasksPath); err == nil {
+		lastMod = info.ModTime()
+	}
+
+	var queue []implementJob
+	var nextDue time.Time                       // Earliest NotBefore of a scheduled task
+	claimedIDs := make(map[string]bool)         // Tasks queued by this watch, never claimed again
+	taskJobs := make(map[string][]implementJob) // Jobs of each claimed task
+	jobsLeft := make(map[string]int)            // Jobs of each claimed task still to finish
+	createFailures := make(map[string]int)      // Worktrees of each task git could not create
+	running := 0
+	results := make(chan implementResult)
+	scan := true
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if scan {
+			scan = false
+			var claimed []Task
+			claimed, nextDue, err = claimReadyTasks(numInstances, claimedIDs)
+			if err != nil {
+				fmt.Printf("%s could not check for new tasks: %v\n", errorStyle.Render("Warning:"), err)
+			}
+
+			warnLargePrompts(claimed, agentTemplate, "")
+			issues := newIssueSync(gitRoot)
+			for _, t := range claimed {
+				fmt.Printf("  %s %s %s\n", statusInProgressStyle.Render("[queued]"), idStyle.Render(t.ID), truncate(t.Prompt, 50))
+				issues.add(t, fmt.Sprintf("autom8 started implementing this issue as task `%s` (%d instance(s)).", t.ID, numInstances))
+				claimedIDs[t.ID] = true
+				for i := 0; i < numInstances; i++ {
+					job := implementJob{task: t, suffix: fmt.Sprintf("-%d", i+1)}
+					queue = append(queue, job)
+					taskJobs[t.ID] = append(taskJobs[t.ID], job)
+				}
+				jobsLeft[t.ID] = numInstances
+			}
+			issues.flush()
+		}
+
+		for len(queue) > 0 && (maxParallel <= 0 || running < maxParallel) {
+			job := queue[0]
+			queue = queue[1:]
+			running++
+			fmt.Printf("  %s %s\n", statusInProgressStyle.Render("[started]"), worktreeInstanceID("", job.task.ID, job.suffix))
+			go func(j implementJob) {
+				results <- implementResult{job: j, result: implementTaskWithSuffix(j.task, opts, j.baseBranchID, j.suffix)}
+			}(job)
+		}
+
+		select {
+		case r := <-results:
+			running--
+			fmt.Println(r.result.plain())
+			taskID := r.job.task.ID
+			if r.result.Class == failGit {
+				createFailures[taskID]++
+			}
+			if jobsLeft[taskID]--; jobsLeft[taskID] == 0 {
+				resetUncreatedTasks(taskJobs[taskID], map[string]int{taskID: createFailures[taskID]}, worktreesDir)
+				delete(taskJobs, taskID)
+				delete(jobsLeft, taskID)
+				delete(createFailures, taskID)
+			}
+
+		case <-ticker.C:
+			if !nextDue.IsZero() && !time.Now().Before(nextDue) {
+				nextDue = time.Time{}
+				scan = true
+			}
+			info, err := os.Stat(tasksPath)
+			if err != nil {
+				continue
+			}
+			// Rescan once the file has settled so a burst of writes is read once
+			if !info.ModTime().Equal(lastMod) {
+				lastMod = info.ModTime()
+				changedAt = time.Now()
+			} else if !changedAt.IsZero() && time.Since(changedAt) >= watchDebounce {
+				changedAt = time.Time{}
+				scan = true
+			}
+
+		case <-ctx.Done():
+			// A second signal terminates immediately
+			stop()
+			fmt.Println()
+			return stopWatch(queue, running, results)
+		}
+	}
+}
+
+// claimReadyTasks queues the worktrees of pending tasks whose dependency (if
+// any) is completed and whose NotBefore has passed, and returns those tasks
+// along with the earliest NotBefore still ahead (zero if none). The queue
+// entries are the claim: tasks stay pending until a worktree exists (see
+// markTaskStarted), so a task already in the queue, or in skip, is passed
+// over. It all happens under the queue lock, so two runs never claim the
+// same task.
+func claimReadyTasks(instances int, skip map[string]bool) ([]Task, time.Time, error) {
+	var claimed []Task
+	var nextDue time.Time
+	now := time.Now()
+	err := updateQueue(func(jobs []queuedJob) ([]queuedJob, error) {
+		queued := make(map[string]bool)
+		for _, job := range jobs {
+			queued[job.TaskID] = true
+		}
+		tasks, err := loadTasks()
+		if err != nil {
+			return nil, fmt.Errorf("error loading tasks: %w", err)
+		}
+		statusByID := make(map[string]string)
+		for _, t := range tasks {
+			statusByID[t.ID] = t.Status
+		}
+		for _, t := range tasks {
+			if t.Status != "pending" || skip[t.ID] || queued[t.ID] {
+				continue
+			}
+			if t.scheduledAfter(now) {
+				if nextDue.IsZero() || t.NotBefore.Before(nextDue) {
+					nextDue = t.NotBefore
+				}
+				continue
+			}
+			if t.DependsOn != "" && statusByID[t.DependsOn] != "completed" {
+				continue
+			}
+			claimed = append(claimed, t)
+		}
+		if len(claimed) == 0 {
+			return nil, nil
+		}
+		for _, t := range claimed {
+			for i := 0; i < instances; i++ {
+				jobs = append(jobs, queuedJob{Worktree: worktreeInstanceID("", t.ID, fmt.Sprintf("-%d", i+1)), TaskID: t.ID, PID: os.Getpid(), QueuedAt: now})
+			}
+		}
+		return jobs, nil
+	})
+	return claimed, nextDue, err
+}
+
+// stopWatch drops the queue entries of worktrees that never started (their
+// tasks are still pending), then either waits for the (already cancelled)
+// running agents or leaves them running.
+func stopWatch(queue []implementJob, running int, results chan implementResult) error {
+	var names []string
+	for _, job := range queue {
+		names = append(names, worktreeInstanceID("", job.task.ID, job.suffix))
+	}
+	if len(names) > 0 {
+		dequeueWorktrees(names...)
+	}
+
+	if onExitFlag == "detach" {
+		if running > 0 {
+			fmt.Printf("%s leaving %d agent(s) to finish their current iteration; check their worktrees with 'autom8 status'\n", subtitleStyle.Render("Detached:"), running)
+		}
+		return nil
+	}
+
+	if running > 0 {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Stopping %d running agent(s)...", running)))
+	}
+	for ; running > 0; running-- {
+		r := <-results
+		fmt.Println(r.result.plain())
+	}
+
+	fmt.Println(successStyle.Render("Watch stopped."))
+	return nil
+}
+
+// agentWaitDelay bounds how long a killed agent's subprocesses may hold its
+// output open before the agent is given up on
+const agentWaitDelay = 5 * time.Second
+
+// winnerBaseBranchID returns the worktree ID whose branch a dependent should
+// start from under --parent-strategy winner. A completed parent's winner has
+// already been merged, so its dependents start from main ("").
+func winnerBaseBranchID(gitRoot string, parent Task) (string, error) {
+	if parent.Winner != "" {
+		verifyCmd := exec.Command("git", "-C", gitRoot, "rev-parse", "--verify", "--quiet", "refs/heads/autom8/"+parent.Winner)
+		if verifyCmd.Run() == nil {
+			return parent.Winner, nil
+		}
+	}
+	if parent.Status == "completed" {
+		return "", nil
+	}
+	if parent.Winner == "" {
+		return "", fmt.Errorf("task '%s' has no winner yet; run 'autom8 converge %s' first", parent.ID, parent.ID)
+	}
+	return "", fmt.Errorf("winner branch 'autom8/%s' of task '%s' no longer exists", parent.Winner, parent.ID)
+}
+
+// implementOptions holds the settings shared by every worktree in an implement run
+type implementOptions struct {
+	ctx           context.Context         // Cancelling it kills running agents
+	abort         context.CancelCauseFunc // Cancels ctx for the whole run, e.g. on an auth failure (nil: never)
+	gitRoot       string
+	worktreesDir  string
+	label         string
+	agentTemplate string
+	mcpConfig     string
+	promptAppend  string // Transient guidance from --prompt-append
+	verifyAfter   string // Shell command whose success marks the worktree complete
+	autoVerify    bool   // Check TASK COMPLETE with the task's verify command
+	base          string // Branch tasks without a parent worktree start from (default: HEAD)
+	maxIter       int
+	backoff       time.Duration // Wait before the first retry of a rate-limited iteration, see rateLimitWait
+	maxRetries    int           // Rate-limit retries allowed per worktree (0: unlimited)
+	agentArgs     []string      // Extra claude arguments from --agent-args
+	logCap        int64         // Bytes of logs kept per worktree (0: unlimited)
+	progress      *progressDisplay
+	notifier      *notifier
+	budget        *runBudget
+	outcomes      *outcomeCounts
+	events        *eventStream // --json-events
+	detach        bool         // watch --on-exit detach: agents must outlive autom8
+}
+
+// outcomeCounts records worktree results (notification event names) across goroutines
+type outcomeCounts struct {
+	mu        sync.Mutex
+	worktrees map[string][]string
+}
+
+func newOutcomeCounts() *outcomeCounts {
+	return &outcomeCounts{worktrees: make(map[string][]string)}
+}
+
+func (c *outcomeCounts) add(event, worktree string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.worktrees[event] = append(c.worktrees[event], worktree)
+}
+
+func (c *outcomeCounts) get(event string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.worktrees[event])
+}
+
+// names returns the worktrees with the given result, sorted.
+func (c *outcomeCounts) names(event string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := append([]string(nil), c.worktrees[event]...)
+	sort.Strings(names)
+	return names
+}
+
+// printSummary shows how many worktrees ended in each state, naming the
+// failed ones.
+func (c *outcomeCounts) printSummary() {
+	rows := []struct {
+		label, event string
+		style        lipgloss.Style
+	}{
+		{"Completed", "worktree_completed", statusCompletedStyle},
+		{"Stopped", "worktree_stopped", statusPendingStyle},
+		{"Failed", "worktree_failed", errorStyle},
+		{"Skipped", "worktree_skipped", subtitleStyle},
+		{"Cancelled", "worktree_cancelled", subtitleStyle},
+	}
+
+	fmt.Println(titleStyle.Render("Summary"))
+	for _, row := range rows {
+		n := c.get(row.event)
+		if n == 0 && (row.event == "worktree_skipped" || row.event == "worktree_cancelled") {
+			continue
+		}
+		fmt.Printf("  %-10s %s\n", row.label, row.style.Render(strconv.Itoa(n)))
+	}
+	for _, name := range c.names("worktree_failed") {
+		fmt.Printf("    %s %s\n", errorStyle.Render("✗"), name)
+	}
+}
+
+// checkFailures returns an error when more than allowed worktrees failed,
+// so scripts wrapping implement see a non-zero exit status.
+func (c *outcomeCounts) checkFailures(allowed int) error {
+	if failed := c.get("worktree_failed"); failed > allowed {
+		return fmt.Errorf("%d worktree(s) failed (allowed: %d); see their logs with 'autom8 show <worktree>'", failed, allowed)
+	}
+	return nil
+}
+
+// claudeResult is the subset of claude's --output-format json result used here
+type claudeResult struct {
+	Result       string  `json:"result"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+}
+
+// runBudget tracks spend and elapsed time across all worktrees of an
+// implement run. A nil budget is unlimited.
+type runBudget struct {
+	maxUSD  float64
+	maxTime time.Duration
+	start   time.Time
+
+	mu    sync.Mutex
+	spent float64
+}
+
+func newRunBudget(maxUSD float64, maxTime time.Duration) *runBudget {
+	if maxUSD <= 0 && maxTime <= 0 {
+		return nil
+	}
+	return &runBudget{maxUSD: maxUSD, maxTime: maxTime, start: time.Now()}
+}
+
+// tracksCost reports whether iterations need to report their cost.
+func (b *runBudget) tracksCost() bool {
+	return b != nil && b.maxUSD > 0
+}
+
+func (b *runBudget) add(usd float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent += usd
+}
+
+func (b *runBudget) spentUSD() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spent
+}
+
+// exceeded returns why the budget is used up, or "" if there is room left.
+func (b *runBudget) exceeded() string {
+	if b == nil {
+		return ""
+	}
+	if b.maxUSD > 0 {
+		if spent := b.spentUSD(); spent >= b.maxUSD {
+			return fmt.Sprintf("cost budget $%.2f reached", b.maxUSD)
+		}
+	}
+	if b.maxTime > 0 && time.Since(b.start) >= b.maxTime {
+		return fmt.Sprintf("time budget %s reached", b.maxTime)
+	}
+	return ""
+}
+
+// summary describes what the run used against its limits.
+func (b *runBudget) summary() string {
+	var parts []string
+	if b.maxUSD > 0 {
+		parts = append(parts, fmt.Sprintf("$%.2f of $%.2f spent", b.spentUSD(), b.maxUSD))
+	}
+	if b.maxTime > 0 {
+		parts = append(parts, fmt.Sprintf("%s of %s elapsed", time.Since(b.start).Round(time.Second), b.maxTime))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// implementJob is a single worktree to create and run
+type implementJob struct {
+	task         Task
+	baseBranchID string // Instance ID of the parent worktree, empty to branch from main
+	suffix       string
+}
+
+// implementResult is a finished job with its result.
+type implementResult struct {
+	job          implementJob
+	result       worktreeResult
+	parentFailed bool // Skipped because its parent instance did not complete
+}
+
+// instanceGate lets dependents wait for the parent instance they branch
+// from. status is written before done is closed.
+type instanceGate struct {
+	done   chan struct{}
+	status string
+}
+
+// markTaskStarted moves a pending task to in-progress once a worktree for
+// it exists. Later worktrees of the task leave it as it is.
+func markTaskStarted(taskID string) {
+	updateTasks(func(tasks []Task) ([]Task, error) {
+		for i, t := range tasks {
+			if t.ID == taskID && t.Status == "pending" {
+				tasks[i].Status = "in-progress"
+				tasks[i].NotBefore = time.Time{}
+				tasks[i].UpdatedAt = time.Now()
+				return tasks, nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+// tasksQueuedElsewhere returns the IDs of tasks with worktrees planned by
+// another running autom8 process, which may not have created them yet.
+func tasksQueuedElsewhere() map[string]bool {
+	ids := make(map[string]bool)
+	jobs, _ := loadQueue()
+	for _, job := range jobs {
+		if job.PID != os.Getpid() {
+			ids[job.TaskID] = true
+		}
+	}
+	return ids
+}
+
+// resetUncreatedTasks returns in-progress tasks to pending when none of
+// their worktrees could be created in this run and none exist from earlier
+// ones, so a bare implement picks them up again.
+func resetUncreatedTasks(jobs []implementJob, createFailures map[string]int, worktreesDir string) {
+	if len(createFailures) == 0 {
+		return
+	}
+	jobsPerTask := make(map[string]int)
+	for _, job := range jobs {
+		jobsPerTask[job.task.ID]++
+	}
+	names, _ := listWorktreeNames(worktreesDir)
+	hasWorktrees := make(map[string]bool)
+	for _, name := range names {
+		hasWorktrees[parseTaskIDFromWorktreeName(name)] = true
+	}
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		for i, t := range tasks {
+			if n := createFailures[t.ID]; n > 0 && n == jobsPerTask[t.ID] && !hasWorktrees[t.ID] && t.Status == "in-progress" {
+				tasks[i].Status = "pending"
+				tasks[i].UpdatedAt = time.Now()
+				fmt.Printf("  %s %s returned to pending: none of its worktrees could be created\n", subtitleStyle.Render("[reset]"), idStyle.Render(t.ID))
+			}
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		fmt.Printf("%s could not reset tasks without worktrees: %v\n", errorStyle.Render("Warning:"), err)
+	}
+}
+
+// resetParentSkippedTasks returns tasks whose every instance was skipped for
+// a failed parent to pending, since no worktree was created for them.
+func resetParentSkippedTasks(jobs []implementJob, parentSkips map[string]int) {
+	if len(parentSkips) == 0 {
+		return
+	}
+	jobsPerTask := make(map[string]int)
+	for _, job := range jobs {
+		jobsPerTask[job.task.ID]++
+	}
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		for i, t := range tasks {
+			if skips := parentSkips[t.ID]; skips > 0 && skips == jobsPerTask[t.ID] && t.Status == "in-progress" {
+				tasks[i].Status = "pending"
+				tasks[i].UpdatedAt = time.Now()
+				fmt.Printf("  %s %s returned to pending: its parent produced no completed instance\n", subtitleStyle.Render("[reset]"), idStyle.Render(t.ID))
+			}
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		fmt.Printf("%s could not reset skipped tasks: %v\n", errorStyle.Render("Warning:"), err)
+	}
+}
+
+// worktreeResult is how implementing one worktree ended.
+type worktreeResult struct {
+	Worktree   string `json:"worktree"`
+	Status     string `json:"status"` // completed, failed, stopped, skipped or cancelled
+	Iterations int    `json:"iterations"`
+	DurationMS int64  `json:"duration_ms,omitempty"` // Wall time from the agent starting to the result
+	Branch     string `json:"branch,omitempty"`
+	Error      string `json:"error,omitempty"`       // Why the worktree did not complete
+	Class      string `json:"error_class,omitempty"` // Kind of failure, see classifyFailure
+
+	base string // Branch a completed worktree started from
+}
+
+// with sets the result's status and reason.
+func (r worktreeResult) with(status, format string, args ...any) worktreeResult {
+	r.Status = status
+	r.Error = fmt.Sprintf(format, args...)
+	return r
+}
+
+// classed sets the kind of failure behind the result.
+func (r worktreeResult) classed(class string) worktreeResult {
+	r.Class = class
+	return r
+}
+
+// took formats how long the worktree ran, or "-" if it never started.
+func (r worktreeResult) took() string {
+	if r.DurationMS == 0 {
+		return "-"
+	}
+	return formatStepDuration(time.Duration(r.DurationMS) * time.Millisecond)
+}
+
+// plain renders the result as a styled line, the default --output-format.
+func (r worktreeResult) plain() string {
+	switch r.Status {
+	case "completed":
+		return fmt.Sprintf("  %s %s (branch: %s, base: %s, impl iterations: %d, took %s)",
+			successStyle.Render("[completed]"), r.Worktree, highlightStyle.Render(r.Branch), idStyle.Render(r.base), r.Iterations, r.took())
+	case "failed":
+		label := "[error]"
+		if r.Class != "" {
+			label = "[error: " + r.Class + "]"
+		}
+		return fmt.Sprintf("  %s %s (%s, after %s)", errorStyle.Render(label), r.Worktree, r.Error, r.took())
+	case "stopped":
+		return fmt.Sprintf("  %s %s (%s, after %s)", statusPendingStyle.Render("[stopped]"), r.Worktree, r.Error, r.took())
+	case "skipped":
+		return fmt.Sprintf("  %s %s (%s)", subtitleStyle.Render("[skip]"), r.Worktree, r.Error)
+	default:
+		return fmt.Sprintf("  %s %s (%s)", subtitleStyle.Render("["+r.Status+"]"), r.Worktree, r.Error)
+	}
+}
+
+// implementEvent is one line of the implement --json-events stream.
+type implementEvent struct {
+	Time      time.Time       `json:"time"`
+	Type      string          `json:"type"` // waiting, queued, started, iteration, rate_limited, verify, review or finished
+	Task      string          `json:"task"`
+	Worktree  string          `json:"worktree"`
+	Iteration int             `json:"iteration,omitempty"`
+	State     string          `json:"state"`            // As in the progress display; finished: the result's status
+	Result    *worktreeResult `json:"result,omitempty"` // finished
+}
+
+// eventStream writes implement events as JSON lines. The worktree goroutines
+// send on one channel, so each worktree's events come out in order, and a
+// single writer prints each line as soon as it arrives. A nil *eventStream
+// ignores all calls.
+type eventStream struct {
+	ch   chan implementEvent
+	done chan struct{}
+}
+
+func newEventStream(out io.Writer) *eventStream {
+	s := &eventStream{ch: make(chan implementEvent, 64), done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		enc := json.NewEncoder(out)
+		for ev := range s.ch {
+			enc.Encode(ev)
+		}
+	}()
+	return s
+}
+
+func (s *eventStream) send(ev implementEvent) {
+	if s == nil {
+		return
+	}
+	ev.Time = time.Now()
+	if ev.Result != nil {
+		r := *ev.Result // The sender may keep changing its result
+		ev.Result = &r
+	}
+	s.ch <- ev
+}
+
+// close waits for the events sent so far to be written.
+func (s *eventStream) close() {
+	if s == nil {
+		return
+	}
+	close(s.ch)
+	<-s.done
+}
+
+// resultWriter prints worktree results in the --output-format chosen for
+// implement. Tables are printed once every result is in.
+type resultWriter struct {
+	format string
+	out    io.Writer // stdout, even when json moves other output to stderr
+	rows   []worktreeResult
+}
+
+func newResultWriter(format string) (*resultWriter, error) {
+	switch format {
+	case "plain", "json", "table":
+	default:
+		return nil, fmt.Errorf("invalid --output-format '%s': use plain, json or table", format)
+	}
+	return &resultWriter{format: format, out: os.Stdout}, nil
+}
+
+func (w *resultWriter) add(r worktreeResult, progress *progressDisplay) {
+	switch w.format {
+	case "json":
+		data, err := json.Marshal(r)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(w.out, string(data))
+	case "table":
+		w.rows = append(w.rows, r)
+	default:
+		progress.println(r.plain())
+	}
+}
+
+// flush prints the table of results collected so far.
+func (w *resultWriter) flush() {
+	if w.format != "table" || len(w.rows) == 0 {
+		return
+	}
+	fmt.Fprintln(w.out)
+	tw := tabwriter.NewWriter(w.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORKTREE\tSTATUS\tITERATIONS\tDURATION\tBRANCH\tERROR")
+	for _, r := range w.rows {
+		status := r.Status
+		if r.Class != "" {
+			status += ": " + r.Class
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n", r.Worktree, status, r.Iterations, r.took(), r.Branch, strings.ReplaceAll(r.Error, "\n", " "))
+	}
+	tw.Flush()
+	w.rows = nil
+}
+
+// autoConvergeTask converges the worktrees of a task that completed in this
+// implement run, saving the winner and, in merge mode, accepting it. A task
+// whose dependents branched from its worktrees in this run is not merged,
+// since accepting removes the winner's branch. It reports whether the task
+// was merged.
+func autoConvergeTask(conv *converger, task Task, names []string, hasDependents bool, opts implementOptions) bool {
+	completed := make(map[string]bool)
+	for _, name := range opts.outcomes.names("worktree_completed") {
+		completed[name] = true
+	}
+	pids, _ := loadPids()
+	var worktrees []WorktreeInfo
+	for _, name := range names {
+		if completed[name] {
+			worktrees = append(worktrees, getWorktreeInfo(opts.worktreesDir, name, pids))
+		}
+	}
+	if len(worktrees) < 2 {
+		conv.logf("  %s %s (auto-converge: %d of %d worktrees completed, nothing to compare)", subtitleStyle.Render("[skip]"), task.ID, len(worktrees), len(names))
+		return false
+	}
+
+	c := *conv
+	if c.merge && hasDependents {
+		c.merge = false
+		conv.logf("  %s %s has dependents in this run; choosing a winner without merging", subtitleStyle.Render("[auto-converge]"), task.ID)
+	}
+
+	// The analysis takes a while, so tasks.json is not locked meanwhile
+	tasks, err := loadTasks()
+	if err != nil {
+		conv.logf("  %s auto-converge %s: error loading tasks: %v", errorStyle.Render("[error]"), task.ID, err)
+		return false
+	}
+	if c.converge(task, worktrees, tasks) == "" {
+		return false
+	}
+	if err := saveConvergeResult(tasks, task.ID); err != nil {
+		conv.logf("  %s could not save the winner of %s: %v", errorStyle.Render("Warning:"), task.ID, err)
+	}
+	converged := findTask(tasks, task.ID)
+	return converged != nil && converged.Status == "completed"
+}
+
+// saveConvergeResult copies the winner, scores and status converge set on
+// taskID in tasks (loaded before the long analysis) into tasks.json, leaving
+// the other tasks as they are now.
+func saveConvergeResult(tasks []Task, taskID string) error {
+	converged := findTask(tasks, taskID)
+	if converged == nil {
+		return nil
+	}
+	return updateTasks(func(tasks []Task) ([]Task, error) {
+		t := findTask(tasks, taskID)
+		if t == nil {
+			return nil, nil
+		}
+		t.Winner = converged.Winner
+		t.WorktreeScores = converged.WorktreeScores
+		t.Status = converged.Status
+		t.UpdatedAt = time.Now()
+		return tasks, nil
+	})
+}
+
+func implementTaskWithSuffix(task Task, opts implementOptions, baseBranchID, suffix string) (res worktreeResult) {
+	instanceID := worktreeInstanceID(opts.label, task.ID, suffix)
+	worktreePath := filepath.Join(opts.worktreesDir, instanceID)
+
+	branchName := fmt.Sprintf("autom8/%s", instanceID)
+	res = worktreeResult{Worktree: instanceID, Status: "failed"}
+
+	// Frees the agent slot, or drops the job if it never got one
+	defer dequeueWorktrees(instanceID)
+
+	emit := func(typ, state string) {
+		opts.events.send(implementEvent{Type: typ, Task: task.ID, Worktree: instanceID, Iteration: res.Iterations, State: state})
+	}
+	defer func() {
+		opts.events.send(implementEvent{Type: "finished", Task: task.ID, Worktree: instanceID, Iteration: res.Iterations, State: res.Status, Result: &res})
+	}()
+
+	// Check if worktree already exists
+	if _, err := os.Stat(worktreePath); err == nil {
+		markTaskStarted(task.ID)
+		opts.outcomes.add("worktree_skipped", instanceID)
+		return res.with("skipped", "already exists")
+	}
+
+	err := waitForSlot(opts.ctx, instanceID, func(ahead int) {
+		opts.progress.update(instanceID, fmt.Sprintf("queued (%d ahead)", ahead))
+		emit("queued", fmt.Sprintf("queued (%d ahead)", ahead))
+	})
+	if errors.Is(err, errJobCancelled) {
+		opts.outcomes.add("worktree_cancelled", instanceID)
+		return res.with("cancelled", "removed from the queue")
+	} else if opts.ctx.Err() != nil {
+		opts.outcomes.add("worktree_stopped", instanceID)
+		return res.with("stopped", "interrupted while queued")
+	} else if err != nil {
+		opts.outcomes.add("worktree_failed", instanceID)
+		return res.with("failed", "waiting for an agent slot: %v", err)
+	}
+	opts.progress.update(instanceID, "starting")
+	emit("started", "starting")
+
+	start := time.Now()
+	defer func() {
+		res.DurationMS = max(time.Since(start).Milliseconds(), 1)
+		event := "worktree_" + res.Status
+		opts.outcomes.add(event, instanceID)
+		recordWorktreeOutcome(instanceID, event, res.Class)
+		appendEvent(filepath.Join(filepath.Dir(opts.worktreesDir), "logs", instanceID),
+			agentEvent{Type: "finished", Iteration: res.Iterations, Status: res.Status, Detail: res.Error, Class: res.Class, DurationMS: res.DurationMS})
+		ev := notification{Event: event, Task: task, Worktree: instanceID, Duration: time.Since(start)}
+		opts.notifier.send(ev)
+		if err := runHook(ev, strings.TrimPrefix(event, "worktree_")); err != nil {
+			opts.progress.println(fmt.Sprintf("%s %v", errorStyle.Render("Warning:"), err))
+		}
+	}()
+
+	// Determine base branch for worktree creation and review
+	var baseBranch string
+	var cmd *exec.Cmd
+	if baseBranchID != "" {
+		baseBranch = fmt.Sprintf("autom8/%s", baseBranchID)
+		cmd = exec.Command("git", "-C", opts.gitRoot, "worktree", "add", "-b", branchName, worktreePath, baseBranch)
+	} else if opts.base != "" {
+		baseBranch = opts.base
+		cmd = exec.Command("git", "-C", opts.gitRoot, "worktree", "add", "-b", branchName, worktreePath, opts.base)
+	} else {
+		baseBranch = "main"
+		cmd = exec.Command("git", "-C", opts.gitRoot, "worktree", "add", "-b", branchName, worktreePath)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return res.with("failed", "%v\n%s", err, strings.TrimSpace(string(output))).classed(failGit)
+	}
+	markTaskStarted(task.ID)
+
+	// Mark the worktree as running for as long as this process works on it
+	savePid(instanceID, os.Getpid())
+	defer clearPid(instanceID)
+
+	// Create logs directory for this worktree
+	autom8Path := filepath.Dir(opts.worktreesDir)
+	logsDir := filepath.Join(autom8Path, "logs", instanceID)
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		return res.with("failed", "failed to create logs dir: %v", err)
+	}
+
+	// The agent template goes in the system prompt where the CLI supports it,
+	// so the model keeps instructions and task apart
+	promptArgs := []string{"-p", buildImplementPrompt(task, opts.agentTemplate, opts.promptAppend)}
+	if opts.agentTemplate != "" && claudeSupportsSystemPrompt() {
+		promptArgs = []string{"-p", buildImplementPrompt(task, "", opts.promptAppend), "--append-system-prompt", opts.agentTemplate}
+	}
+	promptBytes := 0
+	for _, arg := range promptArgs {
+		promptBytes += len(arg)
+	}
+
+	// Run claude in a loop until TASK COMPLETE or max iterations
+	iteration := 0
+	retries := 0
+	for {
+		iteration++
+
+		// Check max iterations limit
+		if opts.maxIter > 0 && iteration > opts.maxIter {
+			return res.with("stopped", "max iterations %d reached", opts.maxIter)
+		}
+
+		if reason := opts.budget.exceeded(); reason != "" {
+			return res.with("stopped", "%s after %d iteration(s)", reason, iteration-1)
+		}
+		res.Iterations = iteration
+
+		// Create log file for this iteration
+		logFile := filepath.Join(logsDir, fmt.Sprintf("iteration-%d.log", iteration))
+		iterStart := time.Now()
+		appendEvent(logsDir, agentEvent{Type: "iteration_start", Iteration: iteration, PromptBytes: promptBytes})
+
+		// Run claude synchronously and capture output
+		opts.progress.update(instanceID, fmt.Sprintf("iteration %d", iteration))
+		emit("iteration", fmt.Sprintf("iteration %d", iteration))
+
+		claudeArgs := append(append(append([]string{}, promptArgs...), opts.agentArgs...), "--dangerously-skip-permissions")
+		if opts.budget.tracksCost() {
+			// The JSON result carries the cost of the iteration
+			claudeArgs = append(claudeArgs, "--output-format", "json")
+		}
+		claudeArgs = withMCPConfig(claudeArgs, opts.mcpConfig)
+		claudeCmd := exec.CommandContext(opts.ctx, "claude", claudeArgs...)
+		claudeCmd.Dir = worktreePath
+		claudeCmd.WaitDelay = agentWaitDelay
+
+		// Output goes to the log as it arrives, for 'autom8 attach'; the log
+		// is rewritten with the final transcript once the iteration ends. A
+		// cost-tracking run only prints its JSON result at the end, so its log
+		// stays empty until then rather than showing the raw JSON.
+		var stdoutBuf, stderrBuf bytes.Buffer
+		claudeCmd.Stdout = &stdoutBuf
+		claudeCmd.Stderr = &stderrBuf
+		live, liveErr := os.Create(logFile)
+		var err error
+		if opts.detach && liveErr == nil {
+			err = runDetachedAgent(claudeCmd, instanceID, live, &stdoutBuf, &stderrBuf)
+		} else {
+			if liveErr == nil && !opts.budget.tracksCost() {
+				claudeCmd.Stdout = io.MultiWriter(&stdoutBuf, liveLog{live})
+			}
+			err = claudeCmd.Run()
+		}
+		if liveErr == nil {
+			live.Close()
+		}
+		output := stdoutBuf.Bytes()
+		if err != nil {
+			// Log the error
+			stderr := stderrBuf.Bytes()
+			writeCappedLog(logFile, []byte(fmt.Sprintf("ERROR: %v\n%s%s", err, string(output), string(stderr))), opts.logCap)
+			exitCode := -1
+			if claudeCmd.ProcessState != nil {
+				exitCode = claudeCmd.ProcessState.ExitCode()
+			}
+			class := classifyFailure(opts.ctx, err, string(stderr))
+			appendEvent(logsDir, agentEvent{Type: "iteration_end", Iteration: iteration, ExitCode: &exitCode,
+				DurationMS: time.Since(iterStart).Milliseconds(), Class: class})
+			if opts.ctx.Err() != nil {
+				return res.with("stopped", "interrupted in iteration %d", iteration).classed(class)
+			}
+
+			switch class {
+			case failAuth:
+				// Every other agent would fail the same way, so stop them all
+				if opts.abort != nil {
+					opts.progress.println(fmt.Sprintf("  %s %s could not authenticate; stopping the run",
+						errorStyle.Render("[aborting]"), instanceID))
+					opts.abort(fmt.Errorf("the agent could not authenticate in %s; log in with 'claude' and try again", instanceID))
+				}
+				return res.with("failed", "iteration %d failed: %s", iteration, failureLine(string(stderr)+string(output), err)).classed(class)
+			case failAgentMissing:
+				return res.with("failed", "iteration %d failed: %v", iteration, err).classed(class)
+			}
+
+			// A rate limit is not the agent's fault: wait and retry the same iteration
+			if class == failRateLimited && opts.backoff > 0 {
+				retries++
+				if opts.maxRetries > 0 && retries > opts.maxRetries {
+					return res.with("failed", "iteration %d still rate-limited after %d retries", iteration, opts.maxRetries).classed(class)
+				}
+				attempt := strconv.Itoa(retries)
+				if opts.maxRetries > 0 {
+					attempt += "/" + strconv.Itoa(opts.maxRetries)
+				}
+				wait := rateLimitWait(opts.backoff, retries)
+				opts.progress.println(fmt.Sprintf("  %s %s (iteration %d): backing off for %s (attempt %s)...",
+					statusPendingStyle.Render("[rate-limited]"), instanceID, iteration, formatStepDuration(wait), attempt))
+				appendEvent(logsDir, agentEvent{Type: "rate_limited", Iteration: iteration, Detail: fmt.Sprintf("retrying in %s", wait)})
+				emit("rate_limited", fmt.Sprintf("backing off for %s (attempt %s)", formatStepDuration(wait), attempt))
+				if !backOff(opts.ctx, opts.progress, instanceID, wait, attempt) {
+					return res.with("stopped", "interrupted while rate-limited in iteration %d", iteration)
+				}
+				iteration--
+				continue
+			}
+			return res.with("failed", "iteration %d failed: %v", iteration, err).classed(class)
+		}
+
+		var cost float64
+		if opts.budget.tracksCost() {
+			var result claudeResult
+			if err := json.Unmarshal(output, &result); err == nil {
+				opts.budget.add(result.TotalCostUSD)
+				cost = result.TotalCostUSD
+				output = []byte(result.Result)
+			}
+		}
+
+		// Write output to log file
+		writeCappedLog(logFile, output, opts.logCap)
+
+		// Check if output contains TASK COMPLETE, or with --verify-after
+		// whether the verification command passes
+		complete := strings.Contains(string(output), "TASK COMPLETE")
+		exitCode := 0
+		appendEvent(logsDir, agentEvent{Type: "iteration_end", Iteration: iteration, ExitCode: &exitCode,
+			DurationMS: time.Since(iterStart).Milliseconds(), Marker: complete, CostUSD: cost})
+		if opts.verifyAfter != "" {
+			opts.progress.update(instanceID, fmt.Sprintf("verify %d", iteration))
+			emit("verify", fmt.Sprintf("verify %d", iteration))
+			verifyLog := filepath.Join(logsDir, fmt.Sprintf("verify-%d.log", iteration))
+			verifyStart := time.Now()
+			complete = runVerifyCommand(opts.ctx, opts.verifyAfter, worktreePath, verifyLog)
+			appendEvent(logsDir, agentEvent{Type: "verify", Iteration: iteration, Passed: &complete, DurationMS: time.Since(verifyStart).Milliseconds()})
+			if opts.ctx.Err() != nil {
+				return res.with("stopped", "interrupted while verifying iteration %d", iteration)
+			}
+		} else if complete && opts.autoVerify && task.VerifyCommand != "" {
+			// The agent's word isn't enough: keep iterating until the task's own check passes
+			opts.progress.update(instanceID, fmt.Sprintf("verify %d", iteration))
+			emit("verify", fmt.Sprintf("verify %d", iteration))
+			verifyLog := filepath.Join(logsDir, fmt.Sprintf("verify-%d.log", iteration))
+			verifyStart := time.Now()
+			complete = runVerifyCommand(opts.ctx, task.VerifyCommand, worktreePath, verifyLog)
+			appendEvent(logsDir, agentEvent{Type: "verify", Iteration: iteration, Passed: &complete, DurationMS: time.Since(verifyStart).Milliseconds()})
+			if opts.ctx.Err() != nil {
+				return res.with("stopped", "interrupted while verifying iteration %d", iteration)
+			}
 		}
-	}
+		if complete {
+			// Implementation complete - now start the review loop
+			reviewStart := time.Now()
+			reviewResult := runReviewLoop(opts.ctx, task, worktreePath, logsDir, baseBranch, func(status string) {
+				opts.progress.update(instanceID, status)
+				emit("review", status)
+			})
+			passed := reviewResult == ""
+			appendEvent(logsDir, agentEvent{Type: "review", Iteration: iteration, Passed: &passed,
+				DurationMS: time.Since(reviewStart).Milliseconds(), Detail: reviewResult})
+			if reviewResult != "" && opts.ctx.Err() != nil {
+				return res.with("stopped", "interrupted during review")
+			}
+			if reviewResult != "" {
+				return res.with("failed", "review failed: %s", reviewResult)
+			}
 
-	// Update the task
-	tasks[taskIndex].Prompt = prompt
-	tasks[taskIndex].VerificationCriteria = criteria
-	tasks[taskIndex].DependsOn = dependsOn
+			res.Status, res.Branch, res.base = "completed", branchName, "HEAD"
+			if baseBranchID != "" {
+				res.base = fmt.Sprintf("autom8/%s", baseBranchID)
+			} else if opts.base != "" {
+				res.base = opts.base
+			}
+			return res
+		}
 
-	if err := saveTasks(tasks); err != nil {
-		return fmt.Errorf("error saving task: %w", err)
+		// Continue to next iteration
 	}
+}
 
-	fmt.Println()
-	fmt.Println(successStyle.Render("Task updated successfully!"))
-	fmt.Printf("  %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
-	return nil
+// liveLog copies agent output to its log file, ignoring write errors so
+// a full disk never cuts off the agent's stdout.
+type liveLog struct{ f *os.File }
+
+func (l liveLog) Write(p []byte) (int, error) {
+	l.f.Write(p)
+	return len(p), nil
 }
 
-func runConverge(cmd *cobra.Command, args []string) error {
-	gitRoot, err := getGitRoot()
+// runDetachedAgent runs an agent for watch --on-exit detach: in its own
+// session, writing straight to its log files instead of through pipes, so
+// it finishes its iteration even if autom8 exits. The worktree's PID entry
+// points at the agent meanwhile. Its output is then read back into stdout
+// and stderr.
+func runDetachedAgent(cmd *exec.Cmd, instanceID string, log *os.File, stdout, stderr *bytes.Buffer) error {
+	errPath := strings.TrimSuffix(log.Name(), ".log") + ".stderr"
+	errFile, err := os.Create(errPath)
 	if err != nil {
 		return err
 	}
+	defer os.Remove(errPath)
+	defer errFile.Close()
 
-	tasks, err := loadTasks()
-	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
+	cmd.Stdout = log
+	cmd.Stderr = errFile
+	detachProcess(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
 	}
+	savePid(instanceID, cmd.Process.Pid)
+	err = cmd.Wait()
+	savePid(instanceID, os.Getpid())
 
-	if len(tasks) == 0 {
-		fmt.Println(subtitleStyle.Render("No tasks found."))
-		return nil
+	if data, readErr := os.ReadFile(log.Name()); readErr == nil {
+		stdout.Write(data)
 	}
-
-	// Check if a specific task ID was provided
-	var targetTaskID string
-	if len(args) > 0 {
-		targetTaskID = args[0]
+	if data, readErr := os.ReadFile(errPath); readErr == nil {
+		stderr.Write(data)
 	}
+	return err
+}
 
-	// Get worktrees directory
-	autom8Path, _ := getAutom8Dir()
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	pids, _ := loadPids()
+// rateLimitPattern matches the errors claude prints to stderr when the API
+// rejects a request with HTTP 429. Only stderr is checked: the agent's own
+// output may well mention rate limits or 429 in code it wrote.
+var rateLimitPattern = regexp.MustCompile(`(?i)rate[ _-]?limit|too many requests|\b429\b`)
 
-	// Build map of task ID -> worktrees
-	worktreesByTask := make(map[string][]WorktreeInfo)
-	if entries, err := os.ReadDir(worktreesDir); err == nil {
-		for _, entry := range entries {
-			if !entry.IsDir() {
-				continue
-			}
-			worktreeName := entry.Name()
-			// Extract task ID: task-{timestamp}-{instance} -> task-{timestamp}
-			taskID := worktreeName
-			if lastDash := strings.LastIndex(worktreeName, "-"); lastDash > 0 {
-				taskID = worktreeName[:lastDash]
-			}
-			info := getWorktreeInfo(worktreesDir, worktreeName, pids)
-			worktreesByTask[taskID] = append(worktreesByTask[taskID], info)
+// isRateLimited reports whether a failed agent run hit the API rate limit,
+// judging by its stderr.
+func isRateLimited(stderr string) bool {
+	return rateLimitPattern.MatchString(stderr)
+}
+
+// maxRateLimitWait caps the doubling rate-limit backoff.
+const maxRateLimitWait = 10 * time.Minute
+
+// rateLimitWait returns how long to wait before a worktree's retry-th
+// rate-limit retry: base, doubled for every earlier retry, but no more than
+// maxRateLimitWait unless base itself is larger.
+func rateLimitWait(base time.Duration, retry int) time.Duration {
+	if base >= maxRateLimitWait {
+		return base
+	}
+	wait := base
+	for i := 1; i < retry && wait < maxRateLimitWait; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRateLimitWait)
+}
+
+// backOff waits out a rate-limit backoff, counting down in the worktree's
+// progress line each second on a TTY (elsewhere the caller's line is all
+// that's printed). It reports false if ctx was cancelled meanwhile.
+func backOff(ctx context.Context, p *progressDisplay, name string, wait time.Duration, attempt string) bool {
+	deadline := time.Now().Add(wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		if p != nil && p.tty {
+			left := time.Until(deadline).Round(time.Second)
+			p.update(name, fmt.Sprintf("rate-limited, backing off for %s (attempt %s)...", formatStepDuration(left), attempt))
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		case <-ticker.C:
 		}
 	}
+}
 
-	// Filter tasks to converge
-	var tasksToConverge []Task
-	for _, task := range tasks {
-		if targetTaskID != "" {
-			if task.ID == targetTaskID {
-				tasksToConverge = append(tasksToConverge, task)
-				break
-			}
-		} else {
-			// Only converge tasks with multiple worktrees
-			if len(worktreesByTask[task.ID]) > 1 {
-				tasksToConverge = append(tasksToConverge, task)
-			}
+// authFailurePattern matches what claude prints to stderr when it has no
+// valid credentials: a missing or revoked API key or an expired OAuth login.
+// A bare 401 or "unauthorized" is not enough, since an agent's failing tests
+// or tools print those too, and an auth failure aborts the whole run.
+var authFailurePattern = regexp.MustCompile(`(?i)invalid api key|invalid x-api-key|authentication_error|please run /login|oauth token has expired`)
+
+// Kinds of worktree failure, recorded in events.jsonl and worktree_stats.json
+// and shown in the result line as [error: <class>].
+const (
+	failRateLimited  = "rate-limited"  // The API rejected the request with 429; retried after --backoff
+	failAuth         = "auth"          // No valid credentials; aborts the whole implement run
+	failAgentMissing = "agent-missing" // The claude binary could not be started
+	failTimeout      = "timeout"       // The run was interrupted or its context expired
+	failExit         = "exit"          // The agent exited non-zero for any other reason
+	failGit          = "git"           // git could not create the worktree
+)
+
+// classifyFailure tells what kind of failure an agent run that returned err
+// hit, from the error and the run's stderr. stdout is the agent's own work,
+// which may mention rate limits or API keys for unrelated reasons.
+func classifyFailure(ctx context.Context, err error, stderr string) string {
+	switch {
+	case errors.Is(err, exec.ErrNotFound) || errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission):
+		return failAgentMissing
+	case ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
+		return failTimeout
+	case authFailurePattern.MatchString(stderr):
+		return failAuth
+	case isRateLimited(stderr):
+		return failRateLimited
+	}
+	return failExit
+}
+
+// failureLine returns the last non-empty line of a failed agent's output, or
+// err if it printed nothing.
+func failureLine(output string, err error) string {
+	// Split before cleaning: cleanOutput turns newlines into spaces
+	lines := strings.Split(output, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(cleanOutput(lines[i])); line != "" {
+			return string(clipRunes([]rune(line), 200))
 		}
 	}
+	return err.Error()
+}
 
-	if targetTaskID != "" && len(tasksToConverge) == 0 {
-		return fmt.Errorf("task '%s' not found", targetTaskID)
+// runVerifyCommand runs the --verify-after command in a worktree, writing
+// its output to logFile, and reports whether it exited zero.
+func runVerifyCommand(ctx context.Context, command, worktreePath, logFile string) bool {
+	verifyCmd := hookShellCommand(ctx, command)
+	verifyCmd.Dir = worktreePath
+	verifyCmd.WaitDelay = agentWaitDelay
+
+	output, err := verifyCmd.CombinedOutput()
+	if err != nil {
+		output = append(output, []byte(fmt.Sprintf("\nFAILED: %v\n", err))...)
 	}
+	os.WriteFile(logFile, output, 0644)
+	return err == nil
+}
 
-	if len(tasksToConverge) == 0 {
-		fmt.Println(subtitleStyle.Render("No tasks with multiple worktrees to converge."))
-		return nil
+// loadImplementerTemplate loads the implementer agent template, or returns
+// "" with --no-agent-template so agents get the bare task prompt.
+func loadImplementerTemplate() (string, error) {
+	if noTemplateFlag {
+		fmt.Println(subtitleStyle.Render("Running without the implementer agent template (--no-agent-template)."))
+		return "", nil
 	}
+	return loadAgentTemplate("implementer")
+}
 
-	fmt.Println(titleStyle.Render("Converging Implementations"))
-	fmt.Println()
+// claudeSupportsSystemPrompt reports whether the installed claude CLI accepts
+// --append-system-prompt. Older versions get the agent template prepended to
+// the prompt instead.
+var claudeSupportsSystemPrompt = sync.OnceValue(func() bool {
+	help, err := exec.Command("claude", "--help").Output()
+	return err == nil && strings.Contains(string(help), "--append-system-prompt")
+})
+
+// buildImplementPrompt constructs the implementer prompt from the agent
+// template, the task, its verification criteria and any --prompt-append text.
+// With an empty template it is just the user prompt.
+func buildImplementPrompt(task Task, agentTemplate, promptAppend string) string {
+	var sb strings.Builder
+	if agentTemplate != "" {
+		sb.WriteString(agentTemplate)
+	}
+	sb.WriteString(task.Prompt)
+	if len(task.VerificationCriteria) > 0 {
+		sb.WriteString("\n\n## Verification Criteria\n\n")
+		for _, c := range task.VerificationCriteria {
+			sb.WriteString(fmt.Sprintf("- %s\n", c))
+		}
+	}
+	if promptAppend != "" {
+		if !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n## Additional Guidance\n\n")
+		sb.WriteString(promptAppend)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
 
-	// Process each task
-	for _, task := range tasksToConverge {
-		worktrees := worktreesByTask[task.ID]
+// promptTokenWarning is the estimated prompt size above which implement
+// warns. The prompt is passed as a single argument, which Linux caps at
+// 128 KiB; at four bytes per token that is roughly 32k tokens.
+const promptTokenWarning = 30000
+
+// estimateTokens roughly estimates the token count of a prompt (about four
+// bytes per token). Counting bytes rather than characters keeps the estimate
+// in step with the argument size limit; non-ASCII text also tends to take
+// more tokens per character.
+func estimateTokens(prompt string) int {
+	return (len(prompt) + 3) / 4
+}
 
-		if len(worktrees) == 0 {
-			fmt.Printf("  %s %s (no worktrees)\n", subtitleStyle.Render("[skip]"), task.ID)
-			continue
+// warnLargePrompts prints a warning for each task whose implementer prompt
+// is estimated to exceed promptTokenWarning.
+func warnLargePrompts(tasks []Task, agentTemplate, promptAppend string) {
+	for _, t := range tasks {
+		tokens := estimateTokens(buildImplementPrompt(t, agentTemplate, promptAppend))
+		if tokens > promptTokenWarning {
+			fmt.Printf("%s prompt for %s is ~%d tokens (warning threshold %d); it may exceed the model's limits\n",
+				errorStyle.Render("Warning:"), idStyle.Render(t.ID), tokens, promptTokenWarning)
 		}
+	}
+}
 
-		if len(worktrees) == 1 {
-			fmt.Printf("  %s %s (only one worktree, nothing to compare)\n", subtitleStyle.Render("[skip]"), task.ID)
-			continue
-		}
+// runReviewLoop runs the review loop after implementation completes.
+// It uses codex review to check the implementation and codex exec to fix issues.
+// Returns empty string on success, or an error message on failure.
+func runReviewLoop(ctx context.Context, task Task, worktreePath, logsDir, baseBranch string, onProgress func(status string)) string {
+	// Load the reviewer agent template
+	reviewerTemplate, err := loadAgentTemplate("reviewer")
+	if err != nil {
+		reviewerTemplate = ""
+	}
 
-		// Check if any worktrees are still running
-		anyRunning := false
-		for _, wt := range worktrees {
-			if wt.IsRunning {
-				anyRunning = true
-				break
-			}
-		}
-		if anyRunning {
-			fmt.Printf("  %s %s (agents still running)\n", statusInProgressStyle.Render("[wait]"), task.ID)
-			continue
-		}
+	reviewIteration := 0
+	fixIteration := 0
+
+	for {
+		reviewIteration++
+
+		onProgress(fmt.Sprintf("review %d", reviewIteration))
 
-		fmt.Printf("  %s %s\n", highlightStyle.Render("[analyzing]"), truncate(task.Prompt, 50))
-		fmt.Printf("    %s %s\n", subtitleStyle.Render("ID:"), idStyle.Render(task.ID))
-		fmt.Printf("    %s %d worktrees\n", subtitleStyle.Render("Comparing:"), len(worktrees))
+		// Build the review prompt
+		reviewPrompt := buildReviewPrompt(task, reviewerTemplate)
 
-		// Build the converge prompt
-		convergePrompt := buildConvergePrompt(task, worktrees, gitRoot)
+		// Create log file for this review iteration
+		reviewLogFile := filepath.Join(logsDir, fmt.Sprintf("review-iteration-%d.log", reviewIteration))
 
-		// Run claude to analyze
-		claudeCmd := exec.Command("claude", "-p", convergePrompt, "--output-format", "json")
-		claudeCmd.Dir = gitRoot
+		// Run codex review with base branch
+		codexCmd := exec.CommandContext(ctx, "codex", "review", "--base", baseBranch, reviewPrompt)
+		codexCmd.Dir = worktreePath
+		codexCmd.WaitDelay = agentWaitDelay
 
-		output, err := claudeCmd.Output()
+		output, err := codexCmd.Output()
 		if err != nil {
-			fmt.Printf("    %s failed to run AI analysis: %v\n", errorStyle.Render("[error]"), err)
-			continue
+			// Log the error
+			os.WriteFile(reviewLogFile, []byte(fmt.Sprintf("ERROR: %v\n%s", err, string(output))), 0644)
+			return fmt.Sprintf("review iteration %d failed: %v", reviewIteration, err)
 		}
 
-		// Parse the response to extract the winner
-		winner := parseConvergeResponse(string(output), worktrees)
-		if winner == "" {
-			fmt.Printf("    %s could not determine a winner\n", errorStyle.Render("[error]"))
-			// Print the raw output for debugging
-			fmt.Printf("    %s\n", subtitleStyle.Render("AI response:"))
-			fmt.Printf("    %s\n", string(output))
-			continue
+		// Write output to log file
+		os.WriteFile(reviewLogFile, output, 0644)
+
+		// Check if review is approved
+		if strings.Contains(string(output), "REVIEW APPROVED") {
+			return "" // Success - review approved
 		}
 
-		fmt.Printf("    %s %s\n", successStyle.Render("[winner]"), highlightStyle.Render(winner))
+		// Review found issues - run fix iteration
+		fixIteration++
+		onProgress(fmt.Sprintf("fix %d", fixIteration))
 
-		// Update task with winner
-		for i, t := range tasks {
-			if t.ID == task.ID {
-				tasks[i].Winner = winner
-				break
-			}
-		}
+		// Build fix prompt with reviewer feedback
+		fixPrompt := buildFixPrompt(task, string(output))
 
-		// Auto-merge if flag is set
-		if mergeFlag {
-			fmt.Printf("    %s\n", subtitleStyle.Render("Auto-merging winner..."))
-			// Simulate calling accept
-			if err := doAccept(winner, gitRoot, autom8Path, tasks); err != nil {
-				fmt.Printf("    %s merge failed: %v\n", errorStyle.Render("[error]"), err)
-			} else {
-				fmt.Printf("    %s merged successfully\n", successStyle.Render("[merged]"))
-			}
+		// Create log file for this fix iteration
+		fixLogFile := filepath.Join(logsDir, fmt.Sprintf("fix-iteration-%d.log", fixIteration))
+
+		// Run codex exec to fix issues
+		fixCmd := exec.CommandContext(ctx, "codex", "exec", "--dangerously-bypass-approvals-and-sandbox", fixPrompt)
+		fixCmd.Dir = worktreePath
+		fixCmd.WaitDelay = agentWaitDelay
+
+		fixOutput, err := fixCmd.Output()
+		if err != nil {
+			// Log the error
+			os.WriteFile(fixLogFile, []byte(fmt.Sprintf("ERROR: %v\n%s", err, string(fixOutput))), 0644)
+			return fmt.Sprintf("fix iteration %d failed: %v", fixIteration, err)
 		}
 
-		fmt.Println()
+		// Write output to log file
+		os.WriteFile(fixLogFile, fixOutput, 0644)
+
+		// Continue to next review iteration
 	}
+}
 
-	// Save tasks with winner info
-	if err := saveTasks(tasks); err != nil {
-		return fmt.Errorf("error saving tasks: %w", err)
+// buildReviewPrompt constructs the prompt for the codex review command.
+func buildReviewPrompt(task Task, reviewerTemplate string) string {
+	var sb strings.Builder
+
+	if reviewerTemplate != "" {
+		sb.WriteString(reviewerTemplate)
 	}
 
-	fmt.Println(successStyle.Render("Convergence complete!"))
-	if !mergeFlag {
-		fmt.Println(subtitleStyle.Render("Use 'autom8 accept <worktree>' to merge the winner, or 'autom8 converge --merge' to auto-merge."))
+	sb.WriteString("## Original Task\n\n")
+	sb.WriteString(task.Prompt)
+	sb.WriteString("\n\n")
+
+	if len(task.VerificationCriteria) > 0 {
+		sb.WriteString("## Verification Criteria\n\n")
+		for _, c := range task.VerificationCriteria {
+			sb.WriteString(fmt.Sprintf("- %s\n", c))
+		}
+		sb.WriteString("\n")
 	}
-	return nil
+
+	sb.WriteString("Review the implementation changes and determine if they satisfy all requirements and verification criteria.\n")
+	sb.WriteString("If satisfied, output: REVIEW APPROVED\n")
+	sb.WriteString("If issues found, provide specific feedback for the implementer.\n")
+
+	return sb.String()
 }
 
-func buildConvergePrompt(task Task, worktrees []WorktreeInfo, gitRoot string) string {
+// buildFixPrompt constructs the prompt for fixing issues based on reviewer feedback.
+func buildFixPrompt(task Task, reviewerFeedback string) string {
 	var sb strings.Builder
 
-	sb.WriteString("You are evaluating multiple implementations of the same task to determine which is best.\n\n")
+	// Load implementer template for context
+	implementerTemplate, _ := loadAgentTemplate("implementer")
+	if implementerTemplate != "" {
+		sb.WriteString(implementerTemplate)
+	}
 
-	sb.WriteString("## Task\n\n")
+	sb.WriteString("## Original Task\n\n")
 	sb.WriteString(task.Prompt)
 	sb.WriteString("\n\n")
 
@@ -1816,578 +11688,1131 @@ func buildConvergePrompt(task Task, worktrees []WorktreeInfo, gitRoot string) st
 		sb.WriteString("\n")
 	}
 
-	sb.WriteString("## Implementations\n\n")
-	sb.WriteString("Below are the diffs for each implementation worktree:\n\n")
+	sb.WriteString("## Reviewer Feedback\n\n")
+	sb.WriteString("The code review found the following issues that need to be fixed:\n\n")
+	sb.WriteString(reviewerFeedback)
+	sb.WriteString("\n\n")
 
-	for _, wt := range worktrees {
-		sb.WriteString(fmt.Sprintf("### Worktree: %s\n\n", wt.Name))
+	sb.WriteString("## Your Task\n\n")
+	sb.WriteString("Fix the issues identified by the reviewer. Make the necessary changes to satisfy all verification criteria.\n")
+	sb.WriteString("After making fixes, commit your changes with a descriptive message.\n")
 
-		// Get the diff for this worktree
-		diffCmd := exec.Command("git", "-C", wt.Path, "diff", "main...HEAD")
-		diffOutput, err := diffCmd.Output()
-		if err != nil {
-			sb.WriteString("(could not get diff)\n\n")
-		} else if len(diffOutput) == 0 {
-			sb.WriteString("(no changes from main)\n\n")
-		} else {
-			// Truncate very large diffs
-			diff := string(diffOutput)
-			if len(diff) > 50000 {
-				diff = diff[:50000] + "\n... (truncated)"
-			}
-			sb.WriteString("```diff\n")
-			sb.WriteString(diff)
-			sb.WriteString("\n```\n\n")
-		}
+	return sb.String()
+}
+
+// progressDisplay shows one status line per worktree during implement.
+// On a TTY the lines are redrawn in place; otherwise each update is printed
+// as its own line. A nil *progressDisplay ignores all calls.
+type progressDisplay struct {
+	mu     sync.Mutex
+	tty    bool
+	names  []string
+	status map[string]string
+	drawn  bool
+}
+
+func newProgressDisplay(names []string) *progressDisplay {
+	p := &progressDisplay{
+		tty:    isTerminal(os.Stdout),
+		names:  names,
+		status: make(map[string]string),
+	}
+	for _, name := range names {
+		p.status[name] = "starting"
+	}
+	return p
+}
+
+// update sets the status shown for a worktree.
+func (p *progressDisplay) update(name, status string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.status[name] = status
+	if p.tty {
+		p.redraw()
+	} else {
+		fmt.Printf("  %s: %s\n", name, status)
+	}
+}
+
+// finish marks a worktree as done. Only the TTY view shows this, since the
+// result line is printed separately.
+func (p *progressDisplay) finish(name string) {
+	if p == nil || !p.tty {
+		return
 	}
+	p.update(name, "done")
+}
 
-	sb.WriteString("## Your Task\n\n")
-	sb.WriteString("Analyze each implementation and determine which one best satisfies the task requirements and verification criteria.\n\n")
-	sb.WriteString("Consider:\n")
-	sb.WriteString("- Correctness: Does the implementation actually solve the task?\n")
-	sb.WriteString("- Completeness: Are all verification criteria met?\n")
-	sb.WriteString("- Code quality: Is the code clean, readable, and maintainable?\n")
-	sb.WriteString("- Simplicity: Is the solution appropriately simple without over-engineering?\n\n")
-	sb.WriteString("IMPORTANT: Your response MUST include the exact worktree name of the winner in this format:\n")
-	sb.WriteString("WINNER: <worktree-name>\n\n")
-	sb.WriteString("For example: WINNER: task-123456789-1\n\n")
-	sb.WriteString("Explain your reasoning before declaring the winner.\n")
+// println prints a line above the progress block.
+func (p *progressDisplay) println(line string) {
+	if p == nil {
+		fmt.Println(line)
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	return sb.String()
+	if !p.tty {
+		fmt.Println(line)
+		return
+	}
+	if p.drawn {
+		// Move to the top of the block and clear it before printing
+		fmt.Printf("\033[%dA\r\033[J", len(p.names))
+		p.drawn = false
+	}
+	fmt.Println(line)
+	p.redraw()
 }
 
-func parseConvergeResponse(response string, worktrees []WorktreeInfo) string {
-	// Try to parse JSON response first
-	var jsonResp struct {
-		Result string `json:"result"`
+// redraw rewrites the progress block in place. Callers must hold p.mu.
+func (p *progressDisplay) redraw() {
+	if p.drawn {
+		fmt.Printf("\033[%dA", len(p.names))
 	}
-	if err := json.Unmarshal([]byte(response), &jsonResp); err == nil {
-		response = jsonResp.Result
+	for _, name := range p.names {
+		fmt.Printf("\r\033[K  %s %s\n", subtitleStyle.Render(name+":"), p.status[name])
 	}
+	p.drawn = true
+}
 
-	// Look for "WINNER: <name>" pattern
-	lines := strings.Split(response, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(strings.ToUpper(line), "WINNER:") {
-			winner := strings.TrimSpace(strings.TrimPrefix(line, "WINNER:"))
-			winner = strings.TrimSpace(strings.TrimPrefix(winner, "winner:"))
-			// Clean up any markdown formatting
-			winner = strings.Trim(winner, "`*_")
-			// Verify it's a valid worktree
-			for _, wt := range worktrees {
-				if wt.Name == winner {
-					return winner
-				}
-			}
-		}
-	}
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
 
-	// Fallback: look for any worktree name mentioned as winner
-	responseLower := strings.ToLower(response)
-	for _, wt := range worktrees {
-		// Check if this worktree is mentioned near "winner" or "best"
-		if strings.Contains(responseLower, strings.ToLower(wt.Name)) {
-			idx := strings.Index(responseLower, strings.ToLower(wt.Name))
-			// Check surrounding context for winner-like words
-			start := idx - 50
-			if start < 0 {
-				start = 0
-			}
-			end := idx + len(wt.Name) + 50
-			if end > len(responseLower) {
-				end = len(responseLower)
-			}
-			context := responseLower[start:end]
-			if strings.Contains(context, "winner") || strings.Contains(context, "best") || strings.Contains(context, "recommend") {
-				return wt.Name
-			}
-		}
+func truncate(s string, maxLen int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) <= maxLen {
+		return s
 	}
+	return s[:maxLen-3] + "..."
+}
 
-	return ""
+// apiServer serves the JSON API for 'autom8 serve'. Long-running actions
+// (implement, converge, accept) run the autom8 binary itself, so they behave
+// exactly like the CLI commands.
+type apiServer struct {
+	token      string
+	autom8Path string
+	executable string
 }
 
-func doAccept(worktreeName, gitRoot, autom8Path string, tasks []Task) error {
-	worktreePath := filepath.Join(autom8Path, "worktrees", worktreeName)
+// taskRequest is the body of task create and edit requests. Omitted fields
+// are left unchanged on edit.
+type taskRequest struct {
+	Prompt               *string   `json:"prompt"`
+	VerificationCriteria *[]string `json:"verification_criteria"`
+	DependsOn            *string   `json:"depends_on"`
+	Status               *string   `json:"status"`
+	AllowCompletedDep    bool      `json:"allow_completed_dep"` // Allow depending on a completed task
+}
 
-	// Check if worktree exists
-	if _, err := os.Stat(worktreePath); os.IsNotExist(err) {
-		return fmt.Errorf("worktree '%s' not found", worktreeName)
+// actionRequest holds the optional settings for implement, converge and accept
+type actionRequest struct {
+	Instances     int    `json:"instances"`
+	MaxIterations int    `json:"max_iterations"`
+	Merge         bool   `json:"merge"`
+	Into          string `json:"into"`
+	Push          bool   `json:"push"`
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	token := cfg.Server.Token
+	if token == "" {
+		token = os.Getenv("AUTOM8_SERVER_TOKEN")
+	}
+	if token == "" {
+		return fmt.Errorf("no API token configured; set server.token in %s/%s or AUTOM8_SERVER_TOKEN", autom8Dir, configFile)
 	}
 
-	// Get the branch name from the worktree
-	branchCmd := exec.Command("git", "-C", worktreePath, "branch", "--show-current")
-	branchOutput, err := branchCmd.Output()
+	host, _, err := net.SplitHostPort(serveAddr)
 	if err != nil {
-		return fmt.Errorf("error getting branch name: %w", err)
+		return fmt.Errorf("invalid --addr '%s': %w", serveAddr, err)
+	}
+	if !isLoopbackHost(host) && !allowRemoteFlag {
+		return fmt.Errorf("refusing to listen on non-loopback address '%s'; pass --allow-remote to expose the API to the network", serveAddr)
 	}
-	branchName := strings.TrimSpace(string(branchOutput))
 
-	if branchName == "" {
-		return fmt.Errorf("could not determine branch name for worktree")
+	autom8Path, err := ensureAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error ensuring autom8 dir: %w", err)
 	}
 
-	// Check for uncommitted changes in the worktree
-	statusCmd := exec.Command("git", "-C", worktreePath, "status", "--porcelain")
-	statusOutput, err := statusCmd.Output()
+	executable, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("error checking worktree status: %w", err)
+		return fmt.Errorf("error locating autom8 binary: %w", err)
 	}
 
-	if len(strings.TrimSpace(string(statusOutput))) > 0 {
-		// Stage all changes
-		addCmd := exec.Command("git", "-C", worktreePath, "add", "-A")
-		if _, err := addCmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("error staging changes: %w", err)
-		}
+	s := &apiServer{token: token, autom8Path: autom8Path, executable: executable}
 
-		// Commit with auto-commit message
-		commitCmd := exec.Command("git", "-C", worktreePath, "commit", "-m", "autom8: auto-commit uncommitted changes")
-		if _, err := commitCmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("error committing changes: %w", err)
-		}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:    serveAddr,
+		Handler: s.routes(),
+		// Event streams end when the server shuts down
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
 
-	// Merge the branch into the current branch
-	mergeCmd := exec.Command("git", "-C", gitRoot, "merge", branchName, "-m", fmt.Sprintf("Merge %s (autom8 converge)", branchName))
-	if output, err := mergeCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("error merging branch: %w\n%s", err, string(output))
+	fmt.Println(titleStyle.Render("autom8 API"))
+	fmt.Println()
+	fmt.Printf("  %s http://%s/api\n", subtitleStyle.Render("Listening on:"), serveAddr)
+	fmt.Println()
+	fmt.Println(subtitleStyle.Render("Press Ctrl+C to stop."))
+
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
 	}
+	return nil
+}
 
-	// Remove the worktree
-	removeCmd := exec.Command("git", "-C", gitRoot, "worktree", "remove", worktreePath)
-	if _, err := removeCmd.CombinedOutput(); err != nil {
-		// Non-fatal, continue
+// isLoopbackHost reports whether a listen host only accepts local connections.
+// An empty host listens on every interface.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
 	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
 
-	// Delete the branch
-	deleteBranchCmd := exec.Command("git", "-C", gitRoot, "branch", "-d", branchName)
-	deleteBranchCmd.Run()
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tasks", s.handleListTasks)
+	mux.HandleFunc("POST /api/tasks", s.handleCreateTask)
+	mux.HandleFunc("GET /api/tasks/{id}", s.handleGetTask)
+	mux.HandleFunc("PATCH /api/tasks/{id}", s.handleEditTask)
+	mux.HandleFunc("DELETE /api/tasks/{id}", s.handleDeleteTask)
+	mux.HandleFunc("POST /api/tasks/{id}/implement", s.handleImplement)
+	mux.HandleFunc("POST /api/tasks/{id}/converge", s.handleConverge)
+	mux.HandleFunc("GET /api/worktrees", s.handleListWorktrees)
+	mux.HandleFunc("POST /api/worktrees/{name}/accept", s.handleAccept)
+	mux.HandleFunc("GET /api/worktrees/{name}/logs", s.handleWorktreeLogs)
+	mux.HandleFunc("GET /api/status/stream", s.handleStatusStream)
+	return s.authenticate(mux)
+}
 
-	// Mark the task as completed
-	taskID := worktreeName
-	if lastDash := strings.LastIndex(worktreeName, "-"); lastDash > 0 {
-		taskID = worktreeName[:lastDash]
-	}
+// authenticate requires the API token as a bearer token, or as a token query
+// parameter for clients that can't set headers (e.g. browser EventSource).
+func (s *apiServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" {
+			token = r.URL.Query().Get("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
 
-	for i, t := range tasks {
-		if t.ID == taskID {
-			tasks[i].Status = "completed"
-			break
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// findTask returns the task with the given ID, or nil.
+func findTask(tasks []Task, id string) *Task {
+	for i := range tasks {
+		if tasks[i].ID == id {
+			return &tasks[i]
 		}
 	}
-
 	return nil
 }
 
-func runImplement(cmd *cobra.Command, args []string) error {
-	// Check git repo first
-	if _, err := getGitRoot(); err != nil {
-		return err
+func (s *apiServer) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := loadTasks()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("error loading tasks: %v", err))
+		return
 	}
+	writeJSON(w, http.StatusOK, tasks)
+}
 
-	if numInstances < 1 {
-		numInstances = 1
+func (s *apiServer) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	tasks, err := loadTasks()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("error loading tasks: %v", err))
+		return
+	}
+	task := findTask(tasks, r.PathValue("id"))
+	if task == nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("task '%s' not found", r.PathValue("id")))
+		return
 	}
 
-	// Check if a specific task ID was provided
-	var targetTaskID string
-	if len(args) > 0 {
-		targetTaskID = args[0]
+	worktrees := loadWorktreesByTask()[task.ID]
+	if worktrees == nil {
+		worktrees = []WorktreeInfo{}
 	}
+	writeJSON(w, http.StatusOK, map[string]any{"task": task, "worktrees": worktrees})
+}
 
-	tasks, err := loadTasks()
-	if err != nil {
-		return fmt.Errorf("error loading tasks: %w", err)
+func (s *apiServer) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	var req taskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.Prompt == nil || strings.TrimSpace(*req.Prompt) == "" {
+		writeAPIError(w, http.StatusBadRequest, "prompt is required")
+		return
 	}
 
-	if len(tasks) == 0 {
-		fmt.Println(subtitleStyle.Render("No tasks found. Use 'autom8 new' to create one."))
-		return nil
+	var criteria []string
+	if req.VerificationCriteria != nil {
+		criteria = *req.VerificationCriteria
+	}
+	var dependsOn string
+	if req.DependsOn != nil {
+		dependsOn = *req.DependsOn
 	}
+	task, err := createTask(*req.Prompt, criteria, dependsOn, req.AllowCompletedDep)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, task)
+}
 
-	// Filter tasks to implement
-	var pendingTasks []Task
-	for _, task := range tasks {
-		// If a specific task ID was provided, only include that task
-		if targetTaskID != "" {
-			if task.ID == targetTaskID {
-				if task.Status == "completed" {
-					return fmt.Errorf("task '%s' is already completed", targetTaskID)
+// createTask adds a pending task for the API and MCP servers.
+func createTask(prompt string, criteria []string, dependsOn string, allowCompletedDep bool) (Task, error) {
+	var task Task
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		task = Task{
+			ID:                   newTaskID(tasks),
+			Prompt:               strings.TrimSpace(prompt),
+			VerificationCriteria: criteria,
+			CreatedAt:            time.Now(),
+			Status:               "pending",
+		}
+		if task.Prompt == "" {
+			return nil, fmt.Errorf("prompt is required")
+		}
+		if err := checkDependency(tasks, "", dependsOn, allowCompletedDep); err != nil {
+			return nil, err
+		}
+		task.DependsOn = dependsOn
+		return append(tasks, task), nil
+	})
+	return task, err
+}
+
+func (s *apiServer) handleEditTask(w http.ResponseWriter, r *http.Request) {
+	var req taskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	id := r.PathValue("id")
+	status := http.StatusBadRequest
+	var edited Task
+	err := updateTasks(func(tasks []Task) ([]Task, error) {
+		task := findTask(tasks, id)
+		if task == nil {
+			status = http.StatusNotFound
+			return nil, fmt.Errorf("task '%s' not found", id)
+		}
+		if req.Prompt != nil {
+			if strings.TrimSpace(*req.Prompt) == "" {
+				return nil, fmt.Errorf("prompt cannot be empty")
+			}
+			task.Prompt = strings.TrimSpace(*req.Prompt)
+		}
+		if req.VerificationCriteria != nil {
+			task.VerificationCriteria = *req.VerificationCriteria
+		}
+		if req.DependsOn != nil && *req.DependsOn != task.DependsOn {
+			if err := checkDependency(tasks, id, *req.DependsOn, req.AllowCompletedDep); err != nil {
+				return nil, err
+			}
+			task.DependsOn = *req.DependsOn
+		}
+		if req.Status != nil {
+			valid := false
+			for _, v := range validStatuses {
+				if v == *req.Status {
+					valid = true
+					break
 				}
-				pendingTasks = append(pendingTasks, task)
-				break
 			}
-		} else if task.Status == "pending" {
-			pendingTasks = append(pendingTasks, task)
+			if !valid {
+				return nil, fmt.Errorf("invalid status '%s' (expected one of: %s)", *req.Status, strings.Join(validStatuses, ", "))
+			}
+			task.Status = *req.Status
 		}
+		task.UpdatedAt = time.Now()
+		edited = *task
+		return tasks, nil
+	})
+	if err != nil {
+		writeAPIError(w, status, err.Error())
+		return
 	}
+	writeJSON(w, http.StatusOK, edited)
+}
 
-	if targetTaskID != "" && len(pendingTasks) == 0 {
-		return fmt.Errorf("task '%s' not found", targetTaskID)
+func (s *apiServer) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	tasks, err := loadTasks()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("error loading tasks: %v", err))
+		return
 	}
-
-	if len(pendingTasks) == 0 {
-		fmt.Println(subtitleStyle.Render("No pending tasks to implement."))
-		return nil
+	if findTask(tasks, id) == nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("task '%s' not found", id))
+		return
+	}
+	for _, t := range tasks {
+		if t.DependsOn == id {
+			writeAPIError(w, http.StatusConflict, fmt.Sprintf("task '%s' depends on '%s'; delete it first", t.ID, id))
+			return
+		}
 	}
 
 	gitRoot, err := getGitRoot()
 	if err != nil {
-		return err
-	}
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// Same cleanup as 'autom8 delete': worktrees with a running agent are
+	// left alone, everything else goes with its PID, stats and logs
+	deleted := map[string]bool{id: true}
+	plan := planTaskDeletion(gitRoot, s.autom8Path, deleted)
+	removed, left := plan.apply(gitRoot, filepath.Join(s.autom8Path, "worktrees"), false)
+	if err := removeTasks(deleted); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := removeTaskLogs(s.autom8Path, plan.logDirs(left), id); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if left == nil {
+		left = []string{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"deleted": id, "worktrees_removed": removed, "worktrees_left": left})
+}
 
-	autom8Path, err := ensureAutom8Dir()
-	if err != nil {
-		return fmt.Errorf("error ensuring autom8 dir: %w", err)
+func (s *apiServer) handleImplement(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeActionRequest(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	if !s.requireTask(w, id) {
+		return
 	}
 
-	worktreesDir := filepath.Join(autom8Path, "worktrees")
-	if err := os.MkdirAll(worktreesDir, 0755); err != nil {
-		return fmt.Errorf("error creating worktrees dir: %w", err)
+	args := []string{"implement", id}
+	if req.Instances > 0 {
+		args = append(args, "-n", strconv.Itoa(req.Instances))
 	}
+	if req.MaxIterations > 0 {
+		args = append(args, "-m", strconv.Itoa(req.MaxIterations))
+	}
+	s.startAction(w, "implement", id, args)
+}
 
-	// Build task map for dependency lookup
-	taskMap := make(map[string]Task)
-	for _, t := range tasks {
-		taskMap[t.ID] = t
+func (s *apiServer) handleConverge(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeActionRequest(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	if !s.requireTask(w, id) {
+		return
 	}
 
-	// Separate tasks with and without dependencies
-	var independentTasks []Task
-	var dependentTasks []Task
-	for _, task := range pendingTasks {
-		if task.DependsOn == "" {
-			independentTasks = append(independentTasks, task)
-		} else {
-			dependentTasks = append(dependentTasks, task)
-		}
+	args := []string{"converge", id}
+	if req.Merge {
+		args = append(args, "--merge")
 	}
+	s.startAction(w, "converge", id, args)
+}
 
-	// Calculate total instances (exponential for dependencies)
-	totalIndependent := len(independentTasks) * numInstances
-	totalDependent := len(dependentTasks) * numInstances * numInstances
+// handleAccept runs accept to completion, since it is quick and callers
+// need to know whether the merge succeeded.
+func (s *apiServer) handleAccept(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeActionRequest(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("name")
+	if _, err := os.Stat(filepath.Join(s.autom8Path, "worktrees", filepath.Base(name))); err != nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("worktree '%s' not found", name))
+		return
+	}
 
-	fmt.Println(titleStyle.Render("Starting Implementation"))
-	fmt.Println()
-	fmt.Printf("  %s %d\n", subtitleStyle.Render("Instances per task:"), numInstances)
-	fmt.Printf("  %s %d task(s) x %d = %d worktrees\n",
-		subtitleStyle.Render("Independent:"), len(independentTasks), numInstances, totalIndependent)
-	if len(dependentTasks) > 0 {
-		fmt.Printf("  %s %d task(s) x %d^2 = %d worktrees (exponential)\n",
-			subtitleStyle.Render("Dependent:"), len(dependentTasks), numInstances, totalDependent)
+	// A client that disconnects must not kill accept halfway through a merge
+	output, err := runAcceptCommand(context.WithoutCancel(r.Context()), s.executable, s.autom8Path, name, req.Into, req.Push)
+	if err != nil {
+		writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error(), "output": output})
+		return
 	}
-	fmt.Println()
+	writeJSON(w, http.StatusOK, map[string]string{"worktree": name, "output": output})
+}
 
-	// Mark all pending tasks as in-progress before starting
-	for i, t := range tasks {
-		for _, pt := range pendingTasks {
-			if t.ID == pt.ID {
-				tasks[i].Status = "in-progress"
-				break
-			}
-		}
+// runAcceptCommand runs 'autom8 accept' to completion and returns its output.
+func runAcceptCommand(ctx context.Context, executable, autom8Path, worktree, into string, push bool) (string, error) {
+	if worktree == "" || worktree != filepath.Base(worktree) {
+		return "", fmt.Errorf("invalid worktree name '%s'", worktree)
 	}
-	if err := saveTasks(tasks); err != nil {
-		return fmt.Errorf("error updating task status: %w", err)
+	if _, err := os.Stat(filepath.Join(autom8Path, "worktrees", worktree)); err != nil {
+		return "", fmt.Errorf("worktree '%s' not found", worktree)
 	}
 
-	// Load the implementer agent template
-	agentTemplate, err := loadAgentTemplate("implementer")
+	args := []string{"accept", worktree}
+	if into != "" {
+		args = append(args, "--into", into)
+	}
+	if push {
+		args = append(args, "--push")
+	}
+	output, err := exec.CommandContext(ctx, executable, args...).CombinedOutput()
 	if err != nil {
-		// Template is optional, continue without it
-		agentTemplate = ""
+		return string(output), fmt.Errorf("accept failed: %w", err)
 	}
+	return string(output), nil
+}
 
-	var wg sync.WaitGroup
-	results := make(chan string, totalIndependent+totalDependent)
+func decodeActionRequest(w http.ResponseWriter, r *http.Request) (actionRequest, bool) {
+	var req actionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return req, false
+	}
+	return req, true
+}
 
-	// Track created branches for independent tasks
-	independentBranches := make(map[string][]string)
+// requireTask writes a 404 and returns false if the task doesn't exist.
+func (s *apiServer) requireTask(w http.ResponseWriter, id string) bool {
+	tasks, err := loadTasks()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("error loading tasks: %v", err))
+		return false
+	}
+	if findTask(tasks, id) == nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("task '%s' not found", id))
+		return false
+	}
+	return true
+}
 
-	// Start independent tasks in parallel
-	for _, task := range independentTasks {
-		independentBranches[task.ID] = make([]string, numInstances)
-		for i := 0; i < numInstances; i++ {
-			suffix := fmt.Sprintf("-%d", i+1)
-			independentBranches[task.ID][i] = suffix
-			wg.Add(1)
-			go func(t Task, s string) {
-				defer wg.Done()
-				result := implementTaskWithSuffix(t, gitRoot, worktreesDir, "", s, agentTemplate, maxIterations)
-				results <- result
-			}(task, suffix)
-		}
+// startAction runs an autom8 command in the background and responds with
+// 202 Accepted.
+func (s *apiServer) startAction(w http.ResponseWriter, action, taskID string, args []string) {
+	job, err := startBackgroundCommand(s.executable, s.autom8Path, action, taskID, args)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
+	writeJSON(w, http.StatusAccepted, job)
+}
 
-	// Start dependent tasks
-	for _, task := range dependentTasks {
-		depSuffixes := independentBranches[task.DependsOn]
-		if depSuffixes == nil {
-			depSuffixes = make([]string, numInstances)
-			for i := 0; i < numInstances; i++ {
-				depSuffixes[i] = fmt.Sprintf("-%d", i+1)
-			}
-		}
+// backgroundJob is an autom8 command started by serve or mcp
+type backgroundJob struct {
+	ID     string `json:"job_id,omitempty"`
+	Action string `json:"action"`
+	TaskID string `json:"task_id"`
+	PID    int    `json:"pid"`
+	Log    string `json:"log"`
+
+	mu   sync.Mutex
+	done bool
+	err  error
+}
 
-		for _, depSuffix := range depSuffixes {
-			for i := 0; i < numInstances; i++ {
-				suffix := fmt.Sprintf("%s-%d", depSuffix, i+1)
-				wg.Add(1)
-				go func(t Task, ds, s string) {
-					defer wg.Done()
-					baseBranch := fmt.Sprintf("%s%s", t.DependsOn, ds)
-					result := implementTaskWithSuffix(t, gitRoot, worktreesDir, baseBranch, s, agentTemplate, maxIterations)
-					results <- result
-				}(task, depSuffix, suffix)
-			}
-		}
+// startBackgroundCommand runs the autom8 binary with args, writing its
+// output to .autom8/logs/serve/.
+func startBackgroundCommand(executable, autom8Path, action, taskID string, args []string) (*backgroundJob, error) {
+	logsDir := filepath.Join(autom8Path, "logs", "serve")
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating logs dir: %w", err)
+	}
+	logPath := filepath.Join(logsDir, fmt.Sprintf("%s-%s-%d.log", action, taskID, time.Now().Unix()))
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating log file: %w", err)
 	}
 
-	// Wait and collect results
+	actionCmd := exec.Command(executable, args...)
+	actionCmd.Stdout = logFile
+	actionCmd.Stderr = logFile
+	if err := actionCmd.Start(); err != nil {
+		logFile.Close()
+		return nil, fmt.Errorf("error starting %s: %w", action, err)
+	}
+
+	job := &backgroundJob{Action: action, TaskID: taskID, PID: actionCmd.Process.Pid, Log: logPath}
 	go func() {
-		wg.Wait()
-		close(results)
+		err := actionCmd.Wait()
+		logFile.Close()
+		job.mu.Lock()
+		job.done, job.err = true, err
+		job.mu.Unlock()
 	}()
+	return job, nil
+}
 
-	for result := range results {
-		fmt.Println(result)
+// state reports "running", "succeeded" or "failed", with the exit error.
+func (j *backgroundJob) state() (string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	switch {
+	case !j.done:
+		return "running", nil
+	case j.err != nil:
+		return "failed", j.err
+	default:
+		return "succeeded", nil
 	}
-
-	fmt.Println()
-	fmt.Println(successStyle.Render("All implementations complete!"))
-	fmt.Println(subtitleStyle.Render("Use 'autom8 status' to see results."))
-	return nil
 }
 
-func implementTaskWithSuffix(task Task, gitRoot, worktreesDir, baseBranchID, suffix, agentTemplate string, maxIter int) string {
-	instanceID := task.ID + suffix
-	worktreePath := filepath.Join(worktreesDir, instanceID)
+// listWorktrees returns every worktree sorted by name.
+func listWorktrees() []WorktreeInfo {
+	worktrees := []WorktreeInfo{}
+	for _, infos := range loadWorktreesByTask() {
+		worktrees = append(worktrees, infos...)
+	}
+	sort.Slice(worktrees, func(i, j int) bool {
+		return worktrees[i].Name < worktrees[j].Name
+	})
+	return worktrees
+}
 
-	branchName := fmt.Sprintf("autom8/%s", instanceID)
+func (s *apiServer) handleListWorktrees(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, listWorktrees())
+}
 
-	// Check if worktree already exists
-	if _, err := os.Stat(worktreePath); err == nil {
-		return fmt.Sprintf("  %s %s (already exists)", subtitleStyle.Render("[skip]"), instanceID)
+// startEventStream prepares a server-sent events response.
+func startEventStream(w http.ResponseWriter) bool {
+	if _, ok := w.(http.Flusher); !ok {
+		writeAPIError(w, http.StatusInternalServerError, "streaming is not supported")
+		return false
 	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	return true
+}
 
-	// Determine base branch for worktree creation and review
-	var baseBranch string
-	var cmd *exec.Cmd
-	if baseBranchID != "" {
-		baseBranch = fmt.Sprintf("autom8/%s", baseBranchID)
-		cmd = exec.Command("git", "-C", gitRoot, "worktree", "add", "-b", branchName, worktreePath, baseBranch)
-	} else {
-		baseBranch = "main"
-		cmd = exec.Command("git", "-C", gitRoot, "worktree", "add", "-b", branchName, worktreePath)
+// writeEvent sends one server-sent event with a JSON payload.
+func writeEvent(w http.ResponseWriter, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
 	}
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Sprintf("  %s %s: %v\n%s", errorStyle.Render("[error]"), instanceID, err, string(output))
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
 	}
+	w.(http.Flusher).Flush()
+	return nil
+}
 
-	// Create logs directory for this worktree
-	autom8Path := filepath.Dir(worktreesDir)
-	logsDir := filepath.Join(autom8Path, "logs", instanceID)
-	if err := os.MkdirAll(logsDir, 0755); err != nil {
-		return fmt.Sprintf("  %s %s: failed to create logs dir: %v", errorStyle.Render("[error]"), instanceID, err)
+// handleStatusStream sends a status event with all tasks and worktrees
+// whenever either changes.
+func (s *apiServer) handleStatusStream(w http.ResponseWriter, r *http.Request) {
+	if !startEventStream(w) {
+		return
 	}
 
-	// Build the prompt with agent template, task, and verification criteria
-	var promptBuilder strings.Builder
-	if agentTemplate != "" {
-		promptBuilder.WriteString(agentTemplate)
-	}
-	promptBuilder.WriteString(task.Prompt)
-	if len(task.VerificationCriteria) > 0 {
-		promptBuilder.WriteString("\n\n## Verification Criteria\n\n")
-		for _, c := range task.VerificationCriteria {
-			promptBuilder.WriteString(fmt.Sprintf("- %s\n", c))
-		}
-	}
-	prompt := promptBuilder.String()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
 
-	// Run claude in a loop until TASK COMPLETE or max iterations
-	iteration := 0
+	var last []byte
 	for {
-		iteration++
-
-		// Check max iterations limit
-		if maxIter > 0 && iteration > maxIter {
-			return fmt.Sprintf("  %s %s (max iterations %d reached)", statusPendingStyle.Render("[stopped]"), instanceID, maxIter)
+		tasks, err := loadTasks()
+		if err == nil {
+			snapshot := map[string]any{"tasks": tasks, "worktrees": listWorktrees()}
+			if data, err := json.Marshal(snapshot); err == nil && !bytes.Equal(data, last) {
+				last = data
+				if err := writeEvent(w, "status", snapshot); err != nil {
+					return
+				}
+			}
 		}
 
-		// Create log file for this iteration
-		logFile := filepath.Join(logsDir, fmt.Sprintf("iteration-%d.log", iteration))
-
-		// Run claude synchronously and capture output
-		claudeCmd := exec.Command("claude", "-p", prompt, "--dangerously-skip-permissions")
-		claudeCmd.Dir = worktreePath
-
-		output, err := claudeCmd.Output()
-		if err != nil {
-			// Log the error
-			os.WriteFile(logFile, []byte(fmt.Sprintf("ERROR: %v\n%s", err, string(output))), 0644)
-			return fmt.Sprintf("  %s %s (iteration %d failed: %v)", errorStyle.Render("[error]"), instanceID, iteration, err)
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
 		}
+	}
+}
 
-		// Write output to log file
-		os.WriteFile(logFile, output, 0644)
+// handleWorktreeLogs streams a worktree's log files as log events, sending
+// new files and appended output as they appear. With ?follow=false it
+// returns after sending what exists.
+func (s *apiServer) handleWorktreeLogs(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	logsDir := filepath.Join(s.autom8Path, "logs", filepath.Base(name))
+	if _, err := os.Stat(logsDir); err != nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no logs for worktree '%s'", name))
+		return
+	}
+	if !startEventStream(w) {
+		return
+	}
+	follow := r.URL.Query().Get("follow") != "false"
 
-		// Check if output contains TASK COMPLETE
-		if strings.Contains(string(output), "TASK COMPLETE") {
-			// Implementation complete - now start the review loop
-			reviewResult := runReviewLoop(task, worktreePath, logsDir, baseBranch)
-			if reviewResult != "" {
-				return fmt.Sprintf("  %s %s (review failed: %s)", errorStyle.Render("[error]"), instanceID, reviewResult)
-			}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 
-			baseInfo := "HEAD"
-			if baseBranchID != "" {
-				baseInfo = fmt.Sprintf("autom8/%s", baseBranchID)
+	sent := make(map[string]int64)
+	for {
+		entries, _ := os.ReadDir(logsDir)
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil || entry.IsDir() {
+				continue
+			}
+			// An iteration's live log is rewritten with its final transcript
+			// when it ends, which may be shorter: send it again from the start
+			reset := info.Size() < sent[entry.Name()]
+			if reset {
+				sent[entry.Name()] = 0
+			}
+			if info.Size() <= sent[entry.Name()] {
+				continue
+			}
+			f, err := os.Open(filepath.Join(logsDir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			f.Seek(sent[entry.Name()], io.SeekStart)
+			data, _ := io.ReadAll(f)
+			f.Close()
+			sent[entry.Name()] += int64(len(data))
+			if err := writeEvent(w, "log", map[string]any{"file": entry.Name(), "text": string(data), "reset": reset}); err != nil {
+				return
 			}
-			return fmt.Sprintf("  %s %s (branch: %s, base: %s, impl iterations: %d)",
-				successStyle.Render("[completed]"), instanceID, highlightStyle.Render(branchName), idStyle.Render(baseInfo), iteration)
 		}
 
-		// Continue to next iteration
+		if !follow {
+			return
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
 
-// runReviewLoop runs the review loop after implementation completes.
-// It uses codex review to check the implementation and codex exec to fix issues.
-// Returns empty string on success, or an error message on failure.
-func runReviewLoop(task Task, worktreePath, logsDir, baseBranch string) string {
-	// Load the reviewer agent template
-	reviewerTemplate, err := loadAgentTemplate("reviewer")
-	if err != nil {
-		reviewerTemplate = ""
-	}
+// mcpProtocolVersion is the MCP revision implemented by 'autom8 mcp'
+const mcpProtocolVersion = "2025-06-18"
 
-	reviewIteration := 0
-	fixIteration := 0
+// mcpServer exposes autom8 as Model Context Protocol tools over stdio.
+// implement and converge run in the background; their job IDs are polled
+// with get_job.
+type mcpServer struct {
+	autom8Path string
+	executable string
 
-	for {
-		reviewIteration++
+	mu   sync.Mutex
+	jobs map[string]*backgroundJob
+}
 
-		// Build the review prompt
-		reviewPrompt := buildReviewPrompt(task, reviewerTemplate)
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
 
-		// Create log file for this review iteration
-		reviewLogFile := filepath.Join(logsDir, fmt.Sprintf("review-iteration-%d.log", reviewIteration))
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
 
-		// Run codex review with base branch
-		codexCmd := exec.Command("codex", "review", "--base", baseBranch, reviewPrompt)
-		codexCmd.Dir = worktreePath
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
 
-		output, err := codexCmd.Output()
-		if err != nil {
-			// Log the error
-			os.WriteFile(reviewLogFile, []byte(fmt.Sprintf("ERROR: %v\n%s", err, string(output))), 0644)
-			return fmt.Sprintf("review iteration %d failed: %v", reviewIteration, err)
-		}
+// mcpTool describes one tool in a tools/list response
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
 
-		// Write output to log file
-		os.WriteFile(reviewLogFile, output, 0644)
+// mcpToolArgs holds the arguments of every tool; each tool reads its own
+type mcpToolArgs struct {
+	TaskID               string   `json:"task_id"`
+	Prompt               string   `json:"prompt"`
+	VerificationCriteria []string `json:"verification_criteria"`
+	DependsOn            string   `json:"depends_on"`
+	AllowCompletedDep    bool     `json:"allow_completed_dep"`
+	Status               string   `json:"status"`
+	Instances            int      `json:"instances"`
+	MaxIterations        int      `json:"max_iterations"`
+	Merge                bool     `json:"merge"`
+	JobID                string   `json:"job_id"`
+	Worktree             string   `json:"worktree"`
+	Into                 string   `json:"into"`
+	Push                 bool     `json:"push"`
+	MaxChars             int      `json:"max_chars"`
+}
 
-		// Check if review is approved
-		if strings.Contains(string(output), "REVIEW APPROVED") {
-			return "" // Success - review approved
-		}
+func runMCP(cmd *cobra.Command, args []string) error {
+	autom8Path, err := ensureAutom8Dir()
+	if err != nil {
+		return fmt.Errorf("error ensuring autom8 dir: %w", err)
+	}
 
-		// Review found issues - run fix iteration
-		fixIteration++
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error locating autom8 binary: %w", err)
+	}
 
-		// Build fix prompt with reviewer feedback
-		fixPrompt := buildFixPrompt(task, string(output))
+	// stdout carries protocol messages only; anything else printed goes to stderr
+	out := os.Stdout
+	os.Stdout = os.Stderr
 
-		// Create log file for this fix iteration
-		fixLogFile := filepath.Join(logsDir, fmt.Sprintf("fix-iteration-%d.log", fixIteration))
+	s := &mcpServer{autom8Path: autom8Path, executable: executable, jobs: make(map[string]*backgroundJob)}
+	return s.serve(os.Stdin, out)
+}
 
-		// Run codex exec to fix issues
-		fixCmd := exec.Command("codex", "exec", "--dangerously-bypass-approvals-and-sandbox", fixPrompt)
-		fixCmd.Dir = worktreePath
+// serve handles newline-delimited JSON-RPC messages until in is closed.
+func (s *mcpServer) serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(out)
 
-		fixOutput, err := fixCmd.Output()
-		if err != nil {
-			// Log the error
-			os.WriteFile(fixLogFile, []byte(fmt.Sprintf("ERROR: %v\n%s", err, string(fixOutput))), 0644)
-			return fmt.Sprintf("fix iteration %d failed: %v", fixIteration, err)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
 		}
 
-		// Write output to log file
-		os.WriteFile(fixLogFile, fixOutput, 0644)
+		var req jsonRPCRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			encoder.Encode(jsonRPCResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &jsonRPCError{Code: -32700, Message: "parse error"}})
+			continue
+		}
+		if len(req.ID) == 0 {
+			// Notifications (e.g. notifications/initialized) need no reply
+			continue
+		}
 
-		// Continue to next review iteration
+		resp := jsonRPCResponse{JSONRPC: "2.0", ID: req.ID}
+		result, rpcErr := s.handle(req)
+		if rpcErr != nil {
+			resp.Error = rpcErr
+		} else {
+			resp.Result = result
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return err
+		}
 	}
+	return scanner.Err()
 }
 
-// buildReviewPrompt constructs the prompt for the codex review command.
-func buildReviewPrompt(task Task, reviewerTemplate string) string {
-	var sb strings.Builder
+func (s *mcpServer) handle(req jsonRPCRequest) (any, *jsonRPCError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+		version := params.ProtocolVersion
+		if version == "" {
+			version = mcpProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": "autom8", "version": "1"},
+		}, nil
+
+	case "ping":
+		return map[string]any{}, nil
+
+	case "tools/list":
+		return map[string]any{"tools": mcpTools()}, nil
+
+	case "tools/call":
+		var params struct {
+			Name      string      `json:"name"`
+			Arguments mcpToolArgs `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &jsonRPCError{Code: -32602, Message: fmt.Sprintf("invalid params: %v", err)}
+		}
 
-	if reviewerTemplate != "" {
-		sb.WriteString(reviewerTemplate)
-	}
+		result, err := s.callTool(params.Name, params.Arguments)
+		if err != nil {
+			// Tool failures are reported to the model, not as protocol errors
+			text := err.Error()
+			if result != nil {
+				if data, jsonErr := json.Marshal(result); jsonErr == nil {
+					text += "\n" + string(data)
+				}
+			}
+			return map[string]any{
+				"content": []map[string]string{{"type": "text", "text": text}},
+				"isError": true,
+			}, nil
+		}
 
-	sb.WriteString("## Original Task\n\n")
-	sb.WriteString(task.Prompt)
-	sb.WriteString("\n\n")
+		data, _ := json.Marshal(result)
+		return map[string]any{
+			"content":           []map[string]string{{"type": "text", "text": string(data)}},
+			"structuredContent": result,
+		}, nil
 
-	if len(task.VerificationCriteria) > 0 {
-		sb.WriteString("## Verification Criteria\n\n")
-		for _, c := range task.VerificationCriteria {
-			sb.WriteString(fmt.Sprintf("- %s\n", c))
+	default:
+		return nil, &jsonRPCError{Code: -32601, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+// mcpTools lists the tools with JSON schemas for their inputs.
+func mcpTools() []mcpTool {
+	object := func(required []string, properties map[string]any) map[string]any {
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
 		}
-		sb.WriteString("\n")
+		return schema
+	}
+	str := func(description string) map[string]any {
+		return map[string]any{"type": "string", "description": description}
+	}
+	integer := func(description string) map[string]any {
+		return map[string]any{"type": "integer", "minimum": 0, "description": description}
+	}
+	boolean := func(description string) map[string]any {
+		return map[string]any{"type": "boolean", "description": description}
+	}
+
+	return []mcpTool{
+		{
+			Name:        "list_tasks",
+			Description: "List autom8 tasks with their worktrees. Optionally filter by status.",
+			InputSchema: object(nil, map[string]any{
+				"status": map[string]any{"type": "string", "enum": validStatuses, "description": "Only return tasks with this status"},
+			}),
+		},
+		{
+			Name:        "create_task",
+			Description: "Create a pending task.",
+			InputSchema: object([]string{"prompt"}, map[string]any{
+				"prompt":                str("What the implementing agent should do"),
+				"verification_criteria": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Checks the implementation must satisfy"},
+				"depends_on":            str("ID of a task this one builds on"),
+				"allow_completed_dep":   map[string]any{"type": "boolean", "description": "Allow depends_on to name a task that is already completed"},
+			}),
+		},
+		{
+			Name:        "implement_task",
+			Description: "Start implementing a task in the background. Returns a job_id to poll with get_job.",
+			InputSchema: object([]string{"task_id"}, map[string]any{
+				"task_id":        str("Task to implement"),
+				"instances":      integer("Parallel worktrees for the task (default 1)"),
+				"max_iterations": integer("Maximum agent iterations per worktree (default unlimited)"),
+			}),
+		},
+		{
+			Name:        "converge_task",
+			Description: "Start picking the best worktree of a task in the background. Returns a job_id to poll with get_job.",
+			InputSchema: object([]string{"task_id"}, map[string]any{
+				"task_id": str("Task whose worktrees to compare"),
+				"merge":   boolean("Merge the winner when done"),
+			}),
+		},
+		{
+			Name:        "get_job",
+			Description: "Get the state (running, succeeded, failed) and recent output of an implement or converge job, plus the task's worktrees.",
+			InputSchema: object([]string{"job_id"}, map[string]any{
+				"job_id": str("ID returned by implement_task or converge_task"),
+			}),
+		},
+		{
+			Name:        "accept_worktree",
+			Description: "Merge a worktree's branch into the current branch and clean it up.",
+			InputSchema: object([]string{"worktree"}, map[string]any{
+				"worktree": str("Worktree name, e.g. task-123-1"),
+				"into":     str("Merge into this branch instead of the current one"),
+				"push":     boolean("Push the merged-into branch afterwards"),
+			}),
+		},
+		{
+			Name:        "get_logs",
+			Description: "Read a worktree's agent, review and fix logs.",
+			InputSchema: object([]string{"worktree"}, map[string]any{
+				"worktree":  str("Worktree name, e.g. task-123-1"),
+				"max_chars": integer("Keep only the last N characters of each log (default 4000)"),
+			}),
+		},
 	}
+}
 
-	sb.WriteString("Review the implementation changes and determine if they satisfy all requirements and verification criteria.\n")
-	sb.WriteString("If satisfied, output: REVIEW APPROVED\n")
-	sb.WriteString("If issues found, provide specific feedback for the implementer.\n")
+func (s *mcpServer) callTool(name string, args mcpToolArgs) (any, error) {
+	switch name {
+	case "list_tasks":
+		tasks, err := loadTasks()
+		if err != nil {
+			return nil, fmt.Errorf("error loading tasks: %w", err)
+		}
+		worktreesByTask := loadWorktreesByTask()
+		type taskWithWorktrees struct {
+			Task
+			Worktrees []WorktreeInfo `json:"worktrees"`
+		}
+		result := []taskWithWorktrees{}
+		for _, t := range tasks {
+			if args.Status != "" && t.Status != args.Status {
+				continue
+			}
+			worktrees := worktreesByTask[t.ID]
+			if worktrees == nil {
+				worktrees = []WorktreeInfo{}
+			}
+			result = append(result, taskWithWorktrees{Task: t, Worktrees: worktrees})
+		}
+		return map[string]any{"tasks": result}, nil
 
-	return sb.String()
-}
+	case "create_task":
+		task, err := createTask(args.Prompt, args.VerificationCriteria, args.DependsOn, args.AllowCompletedDep)
+		if err != nil {
+			return nil, err
+		}
+		return task, nil
 
-// buildFixPrompt constructs the prompt for fixing issues based on reviewer feedback.
-func buildFixPrompt(task Task, reviewerFeedback string) string {
-	var sb strings.Builder
+	case "implement_task", "converge_task":
+		tasks, err := loadTasks()
+		if err != nil {
+			return nil, fmt.Errorf("error loading tasks: %w", err)
+		}
+		if findTask(tasks, args.TaskID) == nil {
+			return nil, fmt.Errorf("task '%s' not found", args.TaskID)
+		}
 
-	// Load implementer template for context
-	implementerTemplate, _ := loadAgentTemplate("implementer")
-	if implementerTemplate != "" {
-		sb.WriteString(implementerTemplate)
-	}
+		action := strings.TrimSuffix(name, "_task")
+		cmdArgs := []string{action, args.TaskID}
+		if action == "implement" {
+			if args.Instances > 0 {
+				cmdArgs = append(cmdArgs, "-n", strconv.Itoa(args.Instances))
+			}
+			if args.MaxIterations > 0 {
+				cmdArgs = append(cmdArgs, "-m", strconv.Itoa(args.MaxIterations))
+			}
+		} else if args.Merge {
+			cmdArgs = append(cmdArgs, "--merge")
+		}
 
-	sb.WriteString("## Original Task\n\n")
-	sb.WriteString(task.Prompt)
-	sb.WriteString("\n\n")
+		job, err := startBackgroundCommand(s.executable, s.autom8Path, action, args.TaskID, cmdArgs)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		job.ID = fmt.Sprintf("job-%d", len(s.jobs)+1)
+		s.jobs[job.ID] = job
+		s.mu.Unlock()
+		return job, nil
+
+	case "get_job":
+		s.mu.Lock()
+		job := s.jobs[args.JobID]
+		s.mu.Unlock()
+		if job == nil {
+			return nil, fmt.Errorf("job '%s' not found", args.JobID)
+		}
 
-	if len(task.VerificationCriteria) > 0 {
-		sb.WriteString("## Verification Criteria\n\n")
-		for _, c := range task.VerificationCriteria {
-			sb.WriteString(fmt.Sprintf("- %s\n", c))
+		state, jobErr := job.state()
+		output, _ := os.ReadFile(job.Log)
+		result := map[string]any{
+			"job_id":    job.ID,
+			"action":    job.Action,
+			"task_id":   job.TaskID,
+			"state":     state,
+			"output":    tailString(string(output), 4000),
+			"worktrees": loadWorktreesByTask()[job.TaskID],
 		}
-		sb.WriteString("\n")
-	}
+		if jobErr != nil {
+			result["error"] = jobErr.Error()
+		}
+		return result, nil
 
-	sb.WriteString("## Reviewer Feedback\n\n")
-	sb.WriteString("The code review found the following issues that need to be fixed:\n\n")
-	sb.WriteString(reviewerFeedback)
-	sb.WriteString("\n\n")
+	case "accept_worktree":
+		output, err := runAcceptCommand(context.Background(), s.executable, s.autom8Path, args.Worktree, args.Into, args.Push)
+		result := map[string]string{"worktree": args.Worktree, "output": output}
+		return result, err
 
-	sb.WriteString("## Your Task\n\n")
-	sb.WriteString("Fix the issues identified by the reviewer. Make the necessary changes to satisfy all verification criteria.\n")
-	sb.WriteString("After making fixes, commit your changes with a descriptive message.\n")
+	case "get_logs":
+		logsDir := filepath.Join(s.autom8Path, "logs", filepath.Base(args.Worktree))
+		entries, err := os.ReadDir(logsDir)
+		if err != nil {
+			return nil, fmt.Errorf("no logs for worktree '%s'", args.Worktree)
+		}
+		maxChars := args.MaxChars
+		if maxChars <= 0 {
+			maxChars = 4000
+		}
+		type logFile struct {
+			Name      string `json:"name"`
+			Text      string `json:"text"`
+			Truncated bool   `json:"truncated"`
+		}
+		files := []logFile{}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(logsDir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			text := tailString(string(data), maxChars)
+			files = append(files, logFile{Name: entry.Name(), Text: text, Truncated: len(text) < len(data)})
+		}
+		return map[string]any{"worktree": args.Worktree, "files": files}, nil
 
-	return sb.String()
+	default:
+		return nil, fmt.Errorf("unknown tool '%s'", name)
+	}
 }
 
-func truncate(s string, maxLen int) string {
-	s = strings.ReplaceAll(s, "\n", " ")
-	if len(s) <= maxLen {
+// tailString returns at most the last n bytes of s, starting at a rune
+// boundary so a multi-byte character is never split.
+func tailString(s string, n int) string {
+	if len(s) <= n {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	i := len(s) - n
+	for i < len(s) && !utf8.RuneStart(s[i]) {
+		i++
+	}
+	return s[i:]
 }
@@ -0,0 +1,474 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListWorktreeNamesMissingDir(t *testing.T) {
+	names, err := listWorktreeNames(filepath.Join(t.TempDir(), "worktrees"))
+	if err != nil {
+		t.Fatalf("listWorktreeNames on a missing dir: %v", err)
+	}
+	if names != nil {
+		t.Fatalf("listWorktreeNames on a missing dir = %v, want nil", names)
+	}
+}
+
+func TestListWorktreeNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"task-1-1", "task-1-2"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stray-file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := listWorktreeNames(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "task-1-1" || names[1] != "task-1-2" {
+		t.Fatalf("listWorktreeNames = %v, want [task-1-1 task-1-2]", names)
+	}
+}
+
+func TestClassifyFailure(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		err    error
+		stderr string
+		want   string
+	}{
+		{"invalid api key", context.Background(), exitErr, "Invalid API key · Please run /login", failAuth},
+		{"expired login", context.Background(), exitErr, "OAuth token has expired", failAuth},
+		{"bare 401 is not auth", context.Background(), exitErr, "GET /users: 401 Unauthorized", failExit},
+		{"rate limited", context.Background(), exitErr, "API Error: 429 rate limit exceeded", failRateLimited},
+		{"too many requests", context.Background(), exitErr, "Too Many Requests", failRateLimited},
+		{"cancelled", cancelled, exitErr, "", failTimeout},
+		{"deadline", context.Background(), context.DeadlineExceeded, "", failTimeout},
+		{"agent missing", context.Background(), &exec.Error{Name: "claude", Err: exec.ErrNotFound}, "", failAgentMissing},
+		{"other exit", context.Background(), exitErr, "panic: something broke", failExit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyFailure(tt.ctx, tt.err, tt.stderr); got != tt.want {
+				t.Errorf("classifyFailure(%q) = %q, want %q", tt.stderr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailureLine(t *testing.T) {
+	err := errors.New("exit status 1")
+	output := "first line\n\x1b[31mInvalid API key\x1b[0m\n  \n\n"
+	if got := failureLine(output, err); got != "Invalid API key" {
+		t.Errorf("failureLine = %q, want the last non-empty line", got)
+	}
+	if got := failureLine(" \n\n", err); got != err.Error() {
+		t.Errorf("failureLine of blank output = %q, want %q", got, err.Error())
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	tests := []struct {
+		base  time.Duration
+		retry int
+		want  time.Duration
+	}{
+		{time.Minute, 1, time.Minute},
+		{time.Minute, 2, 2 * time.Minute},
+		{time.Minute, 4, 8 * time.Minute},
+		{time.Minute, 5, maxRateLimitWait},
+		{time.Minute, 50, maxRateLimitWait},
+		{time.Hour, 3, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := rateLimitWait(tt.base, tt.retry); got != tt.want {
+			t.Errorf("rateLimitWait(%s, %d) = %s, want %s", tt.base, tt.retry, got, tt.want)
+		}
+	}
+}
+
+// setupFailingWorktreeTask creates a repo in a temp dir with one task whose
+// first worktree branch already exists, so 'git worktree add' fails for it
+// before any agent starts.
+func setupFailingWorktreeTask(t *testing.T) Task {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("GIT_AUTHOR_NAME", "autom8")
+	t.Setenv("GIT_AUTHOR_EMAIL", "autom8@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "autom8")
+	t.Setenv("GIT_COMMITTER_EMAIL", "autom8@example.com")
+	git := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "init")
+
+	// The agent is never reached, but implement checks that it is installed
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	task, err := createTask("do something", nil, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	git("branch", "autom8/"+worktreeInstanceID("", task.ID, "-1"))
+	return task
+}
+
+func assertTaskStatus(t *testing.T, id, want string) {
+	t.Helper()
+	tasks, err := loadTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := findTask(tasks, id); got == nil || got.Status != want {
+		t.Fatalf("task %s = %+v, want status %s", id, got, want)
+	}
+}
+
+// TestImplementKeepsTaskPendingWhenWorktreeAddFails checks that a task whose
+// worktree could not be created is left pending for the next run.
+func TestImplementKeepsTaskPendingWhenWorktreeAddFails(t *testing.T) {
+	task := setupFailingWorktreeTask(t)
+
+	if _, err := implementTasks([]string{task.ID}, ""); err != nil {
+		t.Fatalf("implementTasks: %v", err)
+	}
+	assertTaskStatus(t, task.ID, "pending")
+}
+
+// TestWatchKeepsTaskPendingWhenWorktreeAddFails follows watch's path: the
+// task is claimed through the queue alone, stays pending when its worktree
+// cannot be created, and is not claimed again by the same watch.
+func TestWatchKeepsTaskPendingWhenWorktreeAddFails(t *testing.T) {
+	task := setupFailingWorktreeTask(t)
+
+	claimed, _, err := claimReadyTasks(1, nil)
+	if err != nil {
+		t.Fatalf("claimReadyTasks: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != task.ID {
+		t.Fatalf("claimReadyTasks = %v, want [%s]", claimed, task.ID)
+	}
+	assertTaskStatus(t, task.ID, "pending")
+	if again, _, _ := claimReadyTasks(1, nil); len(again) != 0 {
+		t.Fatalf("claimReadyTasks claimed a queued task again: %v", again)
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	autom8Path, err := getAutom8Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := implementOptions{
+		ctx:          context.Background(),
+		gitRoot:      gitRoot,
+		worktreesDir: filepath.Join(autom8Path, "worktrees"),
+		maxIter:      1,
+		outcomes:     newOutcomeCounts(),
+	}
+	job := implementJob{task: claimed[0], suffix: "-1"}
+	if res := implementTaskWithSuffix(job.task, opts, "", job.suffix); res.Class != failGit {
+		t.Fatalf("implementTaskWithSuffix class = %q, want %q", res.Class, failGit)
+	}
+	resetUncreatedTasks([]implementJob{job}, map[string]int{task.ID: 1}, opts.worktreesDir)
+	assertTaskStatus(t, task.ID, "pending")
+
+	if again, _, _ := claimReadyTasks(1, map[string]bool{task.ID: true}); len(again) != 0 {
+		t.Fatalf("claimReadyTasks ignored skip: %v", again)
+	}
+}
+
+func TestTailString(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 3, "llo"},
+		{"héllo", 4, "llo"}, // the cut would split é
+		{"日本語", 4, "語"},
+		{"日本語", 2, ""},
+	}
+	for _, tt := range tests {
+		if got := tailString(tt.s, tt.n); got != tt.want {
+			t.Errorf("tailString(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 14, 30, 0, 0, time.Local)
+	midnight := time.Date(2026, 3, 15, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"14d", midnight.AddDate(0, 0, -14), false},
+		{"0d", midnight, false},
+		{"2w", midnight.AddDate(0, 0, -14), false},
+		{"36h", now.Add(-36 * time.Hour), false},
+		{"90m", now.Add(-90 * time.Minute), false},
+		{"2026-01-31", time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local), false},
+		{"-1d", time.Time{}, true},
+		{"14days", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSince(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleTime(t *testing.T) {
+	now := time.Date(2026, 3, 15, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		at      string
+		after   time.Duration
+		want    time.Time
+		wantErr bool
+	}{
+		{"neither", "", 0, time.Time{}, false},
+		{"after", "", 6 * time.Hour, now.Add(6 * time.Hour), false},
+		{"later today", "18:00", 0, time.Date(2026, 3, 15, 18, 0, 0, 0, time.Local), false},
+		{"earlier means tomorrow", "09:15", 0, time.Date(2026, 3, 16, 9, 15, 0, 0, time.Local), false},
+		{"now means tomorrow", "14:30", 0, time.Date(2026, 3, 16, 14, 30, 0, 0, time.Local), false},
+		{"date and time", "2026-04-01 08:00", 0, time.Date(2026, 4, 1, 8, 0, 0, 0, time.Local), false},
+		{"date in the past", "2026-03-01 08:00", 0, time.Time{}, true},
+		{"both", "18:00", time.Hour, time.Time{}, true},
+		{"negative after", "", -time.Hour, time.Time{}, true},
+		{"bad clock", "25:00", 0, time.Time{}, true},
+		{"bad format", "6pm", 0, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scheduleTime(tt.at, tt.after, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scheduleTime(%q, %s) error = %v, want error %v", tt.at, tt.after, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("scheduleTime(%q, %s) = %s, want %s", tt.at, tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitPendingTasks(t *testing.T) {
+	base := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	task := func(id, dependsOn string, minute int) Task {
+		return Task{ID: id, DependsOn: dependsOn, CreatedAt: base.Add(time.Duration(minute) * time.Minute)}
+	}
+
+	tests := []struct {
+		name    string
+		pending []Task
+		n       int
+		want    []string
+	}{
+		{"oldest first", []Task{task("c", "", 3), task("a", "", 1), task("b", "", 2)}, 2, []string{"a", "b"}},
+		{"n above count", []Task{task("a", "", 1), task("b", "", 2)}, 5, []string{"a", "b"}},
+		{"dependent after its parent", []Task{task("p", "", 1), task("child", "p", 2), task("x", "", 3)}, 2, []string{"p", "child"}},
+		{"dependent without its parent", []Task{task("x", "", 1), task("p", "", 2), task("child", "p", 3)}, 1, []string{"x"}},
+		{"parent not pending", []Task{task("child", "done", 1), task("x", "", 2)}, 1, []string{"child"}},
+		{"dependent older than its parent", []Task{task("child", "p", 1), task("p", "", 2), task("x", "", 3)}, 2, []string{"p", "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, task := range limitPendingTasks(tt.pending, tt.n) {
+				got = append(got, task.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("limitPendingTasks(n=%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConvergeResponse(t *testing.T) {
+	worktrees := []WorktreeInfo{{Name: "task-1-1"}, {Name: "task-1-2"}, {Name: "task-1-3"}}
+
+	tests := []struct {
+		name       string
+		response   string
+		wantWinner string
+		wantScores map[string]int
+	}{
+		{"winner line", "Both work.\nWINNER: task-1-2", "task-1-2", nil},
+		{"markdown and lower case", "winner: `task-1-3`", "task-1-3", nil},
+		{"winner with scores", "- SCORE: `task-1-1` 6/10\n- score: task-1-2: 8\nWINNER: task-1-1", "task-1-1",
+			map[string]int{"task-1-1": 6, "task-1-2": 8}},
+		{"best score without winner", "SCORE: task-1-1 4\nSCORE: task-1-3 9/10", "task-1-3",
+			map[string]int{"task-1-1": 4, "task-1-3": 9}},
+		{"tied scores pick the first name", "SCORE: task-1-3 7\nSCORE: task-1-2 7", "task-1-2",
+			map[string]int{"task-1-2": 7, "task-1-3": 7}},
+		{"unknown winner", "WINNER: task-9-9", "", nil},
+		{"unknown and out of range scores", "SCORE: task-9-9 8\nSCORE: task-1-1 11", "", nil},
+		{"claude json result", `{"type":"result","result":"WINNER: task-1-1"}`, "task-1-1", nil},
+		{"mentioned as best", "I think task-1-2 is the best of them.", "task-1-2", nil},
+		{"no verdict", "They are all different.", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winner, scores := parseConvergeResponse(tt.response, worktrees)
+			if winner != tt.wantWinner {
+				t.Errorf("winner = %q, want %q", winner, tt.wantWinner)
+			}
+			if len(scores) != len(tt.wantScores) {
+				t.Fatalf("scores = %v, want %v", scores, tt.wantScores)
+			}
+			for name, want := range tt.wantScores {
+				if scores[name] != want {
+					t.Errorf("scores = %v, want %v", scores, tt.wantScores)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCheckDependency(t *testing.T) {
+	// a <- b <- c, and d is completed
+	tasks := []Task{
+		{ID: "a", Status: "pending"},
+		{ID: "b", Status: "pending", DependsOn: "a"},
+		{ID: "c", Status: "pending", DependsOn: "b"},
+		{ID: "d", Status: "completed"},
+	}
+
+	tests := []struct {
+		name           string
+		taskID         string
+		dependsOn      string
+		allowCompleted bool
+		wantErr        string
+	}{
+		{"no dependency", "a", "", false, ""},
+		{"new task", "", "c", false, ""},
+		{"independent task", "a", "d", true, ""},
+		{"itself", "a", "a", false, "itself"},
+		{"missing", "a", "zzz", false, "not found"},
+		{"direct cycle", "a", "b", false, "cycle"},
+		{"indirect cycle", "a", "c", false, "cycle"},
+		{"completed", "a", "d", false, "already completed"},
+		{"completed for a new task", "", "d", false, "already completed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDependency(tasks, tt.taskID, tt.dependsOn, tt.allowCompleted)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkDependency(%q, %q) = %v, want nil", tt.taskID, tt.dependsOn, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkDependency(%q, %q) = %v, want an error containing %q", tt.taskID, tt.dependsOn, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCascadeOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		tasks  []Task
+		taskID string
+		want   []string
+	}{
+		{"no dependents", []Task{{ID: "a"}, {ID: "b"}}, "a", []string{"a"}},
+		{"chain", []Task{{ID: "c", DependsOn: "b"}, {ID: "b", DependsOn: "a"}, {ID: "a"}}, "a", []string{"a", "b", "c"}},
+		{"tree, level by level", []Task{
+			{ID: "a"}, {ID: "b", DependsOn: "a"}, {ID: "d", DependsOn: "b"}, {ID: "c", DependsOn: "a"}, {ID: "x"},
+		}, "a", []string{"a", "b", "c", "d"}},
+		{"middle of a chain", []Task{{ID: "a"}, {ID: "b", DependsOn: "a"}, {ID: "c", DependsOn: "b"}}, "b", []string{"b", "c"}},
+		{"cycle", []Task{{ID: "a", DependsOn: "b"}, {ID: "b", DependsOn: "a"}}, "a", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cascadeOrder(tt.tasks, tt.taskID)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("cascadeOrder(%q) = %v, want %v", tt.taskID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	existing := []Task{{ID: "task-1", Status: "pending"}, {ID: "task-2", Status: "completed"}}
+	entry := func(key, dependsOn string) batchTask {
+		return batchTask{Key: key, Prompt: "do " + key, DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name         string
+		entries      []batchTask
+		allowDoneDep bool
+		wantErr      string
+	}{
+		{"keys in any order", []batchTask{entry("api", "schema"), entry("schema", ""), entry("", "api")}, false, ""},
+		{"existing task", []batchTask{entry("a", "task-1")}, false, ""},
+		{"allowed completed task", []batchTask{entry("a", "task-2")}, true, ""},
+		{"missing prompt", []batchTask{entry("a", ""), {Key: "b"}}, false, "entry 2: prompt is required"},
+		{"duplicate key", []batchTask{entry("a", ""), entry("a", "")}, false, "key 'a' is already used by entry 1"},
+		{"dangling reference", []batchTask{entry("a", "nope")}, false, "neither a key in the file nor an existing task"},
+		{"completed task", []batchTask{entry("a", "task-2")}, false, "already completed"},
+		{"self cycle", []batchTask{entry("a", "a")}, false, "dependency cycle a -> a"},
+		{"longer cycle", []batchTask{entry("a", "c"), entry("b", "a"), entry("c", "b")}, false, "dependency cycle a -> c -> b -> a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(old bool) { allowDoneDep = old }(allowDoneDep)
+			allowDoneDep = tt.allowDoneDep
+
+			err := validateBatch(tt.entries, existing)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateBatch = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateBatch = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
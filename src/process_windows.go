@@ -0,0 +1,79 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+const (
+	// Not exported by the syscall package
+	processQueryLimitedInformation = 0x1000
+	stillActive                    = 259
+	detachedProcess                = 0x00000008 // DETACHED_PROCESS
+)
+
+func isProcessRunning(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(handle)
+
+	// OpenProcess also succeeds for exited processes whose handles are still held,
+	// so check the exit code as well
+	var exitCode uint32
+	if err := syscall.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == stillActive
+}
+
+// interactiveShellCommand honors $SHELL (e.g. Git Bash), then prefers PowerShell,
+// falling back to %ComSpec% (cmd.exe).
+func interactiveShellCommand() *exec.Cmd {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return exec.Command(shell)
+	}
+	for _, name := range []string{"pwsh", "powershell"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return exec.Command(path, "-NoLogo")
+		}
+	}
+	comspec := os.Getenv("ComSpec")
+	if comspec == "" {
+		comspec = "cmd.exe"
+	}
+	return exec.Command(comspec)
+}
+
+// hookShellCommand runs a configured hook command line with %ComSpec% (cmd.exe).
+func hookShellCommand(ctx context.Context, command string) *exec.Cmd {
+	comspec := os.Getenv("ComSpec")
+	if comspec == "" {
+		comspec = "cmd.exe"
+	}
+	return exec.CommandContext(ctx, comspec, "/C", command)
+}
+
+// stopProcess terminates a process; Windows can't deliver SIGTERM.
+func stopProcess(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
+
+// detachProcess starts cmd in a new process group without a console, so a
+// Ctrl+C in the console doesn't reach it and it keeps running after autom8
+// exits.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess}
+}